@@ -0,0 +1,18 @@
+data "bitwarden_export_item" "vpn_credentials" {
+  export_file     = "/backups/bitwarden_encrypted_export.json"
+  export_password = var.export_password
+
+  search = "VPN/Credentials"
+}
+
+# Example of usage of the data source:
+resource "kubernetes_secret" "vpn_credentials" {
+  metadata {
+    name = "vpn-credentials"
+  }
+
+  data = {
+    "USERNAME" = data.bitwarden_export_item.vpn_credentials.username
+    "PASSWORD" = data.bitwarden_export_item.vpn_credentials.password
+  }
+}
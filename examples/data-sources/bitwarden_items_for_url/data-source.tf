@@ -0,0 +1,11 @@
+data "bitwarden_items_for_url" "grafana" {
+  url                    = "https://grafana.example.com/login"
+  filter_organization_id = "54421e78-95cb-40c4-a257-17231a7b6207"
+}
+
+# Example of usage of the data source:
+output "grafana_logins" {
+  value = [
+    for item in data.bitwarden_items_for_url.grafana.items : "${item.name} (${item.uri})"
+  ]
+}
@@ -0,0 +1,13 @@
+data "bitwarden_org_events" "production_access" {
+  start   = "2024-05-01T00:00:00Z"
+  end     = "2024-06-01T00:00:00Z"
+  item_id = bitwarden_item_login.production_database.id
+}
+
+# Example of usage of the data source:
+output "production_database_viewers" {
+  value = distinct([
+    for event in data.bitwarden_org_events.production_access.events : event.acting_user_id
+    if event.type_name == "Cipher_ClientViewed"
+  ])
+}
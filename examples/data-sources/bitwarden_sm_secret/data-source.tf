@@ -0,0 +1,9 @@
+data "bitwarden_sm_secret" "db_password" {
+  key        = "DB_PASSWORD"
+  project_id = "58a3e7b4-6a0f-4a3e-9d1b-b0f90110b2c4"
+}
+
+output "db_password" {
+  value     = data.bitwarden_sm_secret.db_password.value
+  sensitive = true
+}
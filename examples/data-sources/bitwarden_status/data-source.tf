@@ -0,0 +1,10 @@
+data "bitwarden_status" "current" {}
+
+output "bitwarden_cli" {
+  value = {
+    version   = data.bitwarden_status.current.cli_version
+    server    = data.bitwarden_status.current.server_url
+    account   = data.bitwarden_status.current.user_email
+    last_sync = data.bitwarden_status.current.last_sync
+  }
+}
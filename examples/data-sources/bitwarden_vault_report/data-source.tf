@@ -0,0 +1,14 @@
+data "bitwarden_vault_report" "infrastructure" {
+  filter_collection_id = "b7a3c2a0-6f3e-4b43-9d4e-3c8a1f0e2d51"
+  pwned_passwords_path = "${path.module}/pwnedpasswords"
+
+  # Example of usage of the data source, failing the plan when an item needs attention:
+  lifecycle {
+    postcondition {
+      condition = length(self.items) == 0
+      error_message = join("\n", [
+        for item in self.items : "${item.name} (${item.id}): ${join(", ", item.reasons)}"
+      ])
+    }
+  }
+}
@@ -0,0 +1,8 @@
+ephemeral "bitwarden_attachment" "tls_key" {
+  id      = "4d6a41364d6a4dea8ddb1a"
+  item_id = "59575167-4d36-5a58-466e-d9021926df8a"
+}
+
+provider "kubernetes" {
+  client_key = ephemeral.bitwarden_attachment.tls_key.content
+}
@@ -0,0 +1,9 @@
+ephemeral "bitwarden_item_login" "database_admin" {
+  search = "Database Admin"
+}
+
+provider "postgresql" {
+  host     = "db.example.com"
+  username = ephemeral.bitwarden_item_login.database_admin.username
+  password = ephemeral.bitwarden_item_login.database_admin.password
+}
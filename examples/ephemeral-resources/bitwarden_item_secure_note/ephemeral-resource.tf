@@ -0,0 +1,10 @@
+ephemeral "bitwarden_item_secure_note" "ssh_notes" {
+  search = "SSH Config"
+}
+
+resource "aws_ssm_parameter" "ssh_notes" {
+  name             = "/ssh/notes"
+  type             = "SecureString"
+  value_wo         = ephemeral.bitwarden_item_secure_note.ssh_notes.notes
+  value_wo_version = 1
+}
@@ -0,0 +1,7 @@
+data "bitwarden_item_login" "database" {
+  search = "Database"
+}
+
+locals {
+  database_port = provider::bitwarden::field(data.bitwarden_item_login.database, "port")
+}
@@ -0,0 +1,7 @@
+ephemeral "bitwarden_item_login" "admin" {
+  search = "Admin Console"
+}
+
+locals {
+  admin_otp = provider::bitwarden::totp_code(ephemeral.bitwarden_item_login.admin.totp, plantimestamp())
+}
@@ -0,0 +1,10 @@
+data "bitwarden_item_login" "database" {
+  search = "Database"
+}
+
+locals {
+  database_urls = [
+    for uri in data.bitwarden_item_login.database.uri : uri.value
+    if provider::bitwarden::uri_matches(uri.value, uri.match, "https://db.example.com/admin")
+  ]
+}
@@ -0,0 +1 @@
+$ terraform import bitwarden_item_collections.example <item_id>
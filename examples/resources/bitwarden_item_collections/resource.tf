@@ -0,0 +1,17 @@
+data "bitwarden_item_login" "database" {
+  search = "Database"
+}
+
+data "bitwarden_organization" "terraform" {
+  search = "Terraform"
+}
+
+data "bitwarden_org_collection" "platform" {
+  search          = "Platform"
+  organization_id = data.bitwarden_organization.terraform.id
+}
+
+resource "bitwarden_item_collections" "database" {
+  item_id        = data.bitwarden_item_login.database.id
+  collection_ids = [data.bitwarden_org_collection.platform.id]
+}
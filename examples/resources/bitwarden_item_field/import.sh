@@ -0,0 +1 @@
+$ terraform import bitwarden_item_field.example <item_id>/<field_name>
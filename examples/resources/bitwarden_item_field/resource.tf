@@ -0,0 +1,9 @@
+data "bitwarden_item_login" "database" {
+  search = "Database"
+}
+
+resource "bitwarden_item_field" "db_host" {
+  item_id = data.bitwarden_item_login.database.id
+  name    = "db_host"
+  text    = "db.example.com"
+}
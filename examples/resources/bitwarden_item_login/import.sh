@@ -1 +1,6 @@
-$ terraform import bitwarden_item_login.example <login_item_id>
\ No newline at end of file
+$ terraform import bitwarden_item_login.example <login_item_id>
+
+# or by name, optionally scoped to a folder or an organization collection
+$ terraform import bitwarden_item_login.example "name:<item_name>"
+$ terraform import bitwarden_item_login.example "folder:<folder_path>/<item_name>"
+$ terraform import bitwarden_item_login.example "collection:<organization_id>/<collection_path>/<item_name>"
@@ -0,0 +1 @@
+$ terraform import bitwarden_org_policy.example <policy_type>
@@ -0,0 +1,24 @@
+resource "bitwarden_org_policy" "master_password" {
+  type = "master_password"
+
+  master_password {
+    min_complexity   = 3
+    min_length       = 14
+    require_upper    = true
+    require_lower    = true
+    require_numbers  = true
+    enforce_on_login = true
+  }
+}
+
+resource "bitwarden_org_policy" "two_step_login" {
+  type = "two_step_login"
+}
+
+resource "bitwarden_org_policy" "send_options" {
+  type = "send_options"
+
+  send_options {
+    disable_hide_email = true
+  }
+}
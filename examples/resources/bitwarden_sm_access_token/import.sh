@@ -0,0 +1 @@
+$ terraform import bitwarden_sm_access_token.example <machine_account_id>/<access_token_id>
@@ -0,0 +1,14 @@
+resource "bitwarden_sm_machine_account" "ci" {
+  name = "CI"
+}
+
+resource "bitwarden_sm_access_token" "github_actions" {
+  machine_account_id = bitwarden_sm_machine_account.ci.id
+  name               = "GitHub Actions"
+  expiration_date    = "2025-12-31T23:59:59Z"
+}
+
+output "github_actions_token" {
+  value     = bitwarden_sm_access_token.github_actions.value
+  sensitive = true
+}
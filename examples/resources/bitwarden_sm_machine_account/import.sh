@@ -0,0 +1 @@
+$ terraform import bitwarden_sm_machine_account.example <machine_account_id>
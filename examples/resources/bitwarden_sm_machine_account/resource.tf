@@ -0,0 +1,3 @@
+resource "bitwarden_sm_machine_account" "ci" {
+  name = "CI"
+}
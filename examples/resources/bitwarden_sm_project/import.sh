@@ -0,0 +1 @@
+$ terraform import bitwarden_sm_project.example <project_id>
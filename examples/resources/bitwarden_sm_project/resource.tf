@@ -0,0 +1,3 @@
+resource "bitwarden_sm_project" "production" {
+  name = "Production"
+}
@@ -0,0 +1 @@
+$ terraform import bitwarden_sm_project_access.example <project_id>/<machine_account_id>
@@ -0,0 +1,13 @@
+resource "bitwarden_sm_project" "production" {
+  name = "Production"
+}
+
+resource "bitwarden_sm_machine_account" "ci" {
+  name = "CI"
+}
+
+resource "bitwarden_sm_project_access" "ci_production" {
+  project_id         = bitwarden_sm_project.production.id
+  machine_account_id = bitwarden_sm_machine_account.ci.id
+  permission         = "read"
+}
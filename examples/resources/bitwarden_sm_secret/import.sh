@@ -0,0 +1 @@
+$ terraform import bitwarden_sm_secret.example <secret_id>
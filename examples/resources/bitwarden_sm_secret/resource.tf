@@ -0,0 +1,10 @@
+resource "bitwarden_sm_project" "production" {
+  name = "Production"
+}
+
+resource "bitwarden_sm_secret" "db_password" {
+  key        = "DB_PASSWORD"
+  value      = var.db_password
+  note       = "Rotated by Terraform"
+  project_id = bitwarden_sm_project.production.id
+}
@@ -0,0 +1,289 @@
+package bearer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+/*
+* This is the HTTP layer shared by clients of the Bitwarden APIs which log in
+* with client credentials (e.g. organization API keys or machine account
+* access tokens), and send the access token they get as a bearer token.
+ */
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("credentials were rejected")
+)
+
+// tokenExpiryMargin is how long before its expiry an access token is renewed.
+const tokenExpiryMargin = 60 * time.Second
+
+// Credentials are the client credentials used to log in.
+type Credentials struct {
+	ClientID     string
+	ClientSecret string
+	Scope        string
+
+	// Description names the credentials in errors, e.g. 'the access token'.
+	Description string
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewClient returns a client of the API served under apiURL, logging in with
+// the identity server served under identityURL. name designates the API in
+// logs.
+func NewClient(ctx context.Context, name, apiURL, identityURL string, credentials Credentials, opts ...Options) *Client {
+	c := &Client{
+		ctx:             ctx,
+		name:            name,
+		apiURL:          strings.TrimSuffix(apiURL, "/"),
+		identityURL:     strings.TrimSuffix(identityURL, "/"),
+		credentials:     credentials,
+		httpClient:      &http.Client{},
+		retryPolicy:     retry.DefaultPolicy(),
+		errNotFound:     ErrNotFound,
+		errUnauthorized: ErrUnauthorized,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+type Options func(c *Client)
+
+func WithRetryPolicy(policy retry.Policy) Options {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithErrors sets the errors returned when an object isn't found and when
+// the credentials are rejected, for callers to match with errors.Is.
+func WithErrors(notFound, unauthorized error) Options {
+	return func(c *Client) {
+		c.errNotFound = notFound
+		c.errUnauthorized = unauthorized
+	}
+}
+
+// WithLoginHandler sets a function called with the body of every token
+// response, for APIs handing out more than an access token on login.
+func WithLoginHandler(handler func(body []byte) error) Options {
+	return func(c *Client) {
+		c.loginHandler = handler
+	}
+}
+
+type Client struct {
+	ctx             context.Context
+	name            string
+	apiURL          string
+	identityURL     string
+	credentials     Credentials
+	httpClient      *http.Client
+	retryPolicy     retry.Policy
+	errNotFound     error
+	errUnauthorized error
+	loginHandler    func(body []byte) error
+
+	tokenMutex  sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// DoJSON sends a request to the API and decodes its response into out,
+// renewing the access token once if it got rejected.
+func (c *Client) DoJSON(method, path string, query url.Values, in, out interface{}) error {
+	err := c.doJSONWithToken(method, path, query, in, out)
+	if errors.Is(err, c.errUnauthorized) {
+		c.invalidateToken()
+		err = c.doJSONWithToken(method, path, query, in, out)
+	}
+	return err
+}
+
+func (c *Client) doJSONWithToken(method, path string, query url.Values, in, out interface{}) error {
+	token, err := c.Token()
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/%s", c.apiURL, path)
+	if len(query) > 0 {
+		u = fmt.Sprintf("%s?%s", u, query.Encode())
+	}
+
+	var requestBody []byte
+	if in != nil {
+		requestBody, err = json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("unable to marshal request to '%s': %w", path, err)
+		}
+	}
+
+	tflog.Debug(c.ctx, fmt.Sprintf("%s request", c.name), map[string]any{"method": method, "path": path})
+
+	// Retrying a POST which reached the server could create objects twice.
+	policy := c.retryPolicy
+	if method == http.MethodPost {
+		policy = policy.ForNonIdempotent()
+	}
+
+	body, err := retry.Do(policy, func() ([]byte, error) {
+		var reader io.Reader
+		if requestBody != nil {
+			reader = strings.NewReader(string(requestBody))
+		}
+
+		req, err := http.NewRequestWithContext(c.ctx, method, u, reader)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		if requestBody != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		err = retry.CheckResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized {
+			return nil, c.errUnauthorized
+		} else if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %v", c.errNotFound, newAPIError(resp.StatusCode, body))
+		} else if resp.StatusCode >= 300 {
+			return nil, newAPIError(resp.StatusCode, body)
+		}
+		return body, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error calling '%s %s': %w", method, path, err)
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(body, out)
+	if err != nil {
+		return fmt.Errorf("unable to parse response of '%s %s': %w", method, path, err)
+	}
+	return nil
+}
+
+// Token returns a valid access token, logging in with the identity server
+// when needed.
+func (c *Client) Token() (string, error) {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	if len(c.accessToken) > 0 && time.Now().Before(c.tokenExpiry) {
+		return c.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", c.credentials.Scope)
+	form.Set("client_id", c.credentials.ClientID)
+	form.Set("client_secret", c.credentials.ClientSecret)
+
+	body, err := retry.Do(c.retryPolicy, func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(c.ctx, "POST", fmt.Sprintf("%s/connect/token", c.identityURL), strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		err = retry.CheckResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("bad status code %d, body: %s", resp.StatusCode, string(body))
+		}
+		return body, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error logging in with %s: %w", c.credentials.Description, err)
+	}
+
+	var tokenResp tokenResponse
+	err = json.Unmarshal(body, &tokenResp)
+	if err != nil {
+		return "", fmt.Errorf("error unmarshalling token response: %w", err)
+	}
+
+	if c.loginHandler != nil {
+		err = c.loginHandler(body)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	c.accessToken = tokenResp.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn)*time.Second - tokenExpiryMargin)
+	return c.accessToken, nil
+}
+
+func (c *Client) invalidateToken() {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	c.accessToken = ""
+}
+
+func newAPIError(statusCode int, body []byte) error {
+	var errResp errorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && len(errResp.Message) > 0 {
+		return fmt.Errorf("bad status code %d: %s", statusCode, errResp.Message)
+	}
+	return fmt.Errorf("bad status code %d, body: %s", statusCode, string(body))
+}
@@ -0,0 +1,94 @@
+package bearer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+var credentials = Credentials{ClientID: "client-1", ClientSecret: "secret", Scope: "api.test", Description: "the test credentials"}
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *int) {
+	tokensIssued := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/connect/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "api.test", r.PostForm.Get("scope"))
+		if r.PostForm.Get("client_id") != "client-1" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		tokensIssued = tokensIssued + 1
+		fmt.Fprintf(w, `{"access_token": "token-%d", "expires_in": 3600, "token_type": "Bearer"}`, tokensIssued)
+	})
+	mux.HandleFunc("/api/", handler)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &tokensIssued
+}
+
+func TestDoJSONRenewsRejectedTokens(t *testing.T) {
+	server, tokensIssued := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"name": "object"}`))
+	})
+
+	logins := 0
+	c := NewClient(context.Background(), "Test API", server.URL+"/api", server.URL+"/identity", credentials, WithLoginHandler(func(body []byte) error {
+		logins = logins + 1
+		assert.Contains(t, string(body), "access_token")
+		return nil
+	}))
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	assert.NoError(t, c.DoJSON("GET", "objects/1", nil, nil, &out))
+	assert.Equal(t, "object", out.Name)
+	assert.Equal(t, 2, *tokensIssued)
+	assert.Equal(t, 2, logins)
+}
+
+func TestDoJSONErrors(t *testing.T) {
+	errNotFound, errUnauthorized := errors.New("object not found"), errors.New("test credentials rejected")
+
+	posts := 0
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts = posts + 1
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Resource not found."}`))
+	})
+
+	c := NewClient(context.Background(), "Test API", server.URL+"/api", server.URL+"/identity", credentials,
+		WithRetryPolicy(retry.Policy{MaxAttempts: 3, DisableBackoff: true}),
+		WithErrors(errNotFound, errUnauthorized),
+	)
+
+	err := c.DoJSON("GET", "objects/1", nil, nil, nil)
+	assert.ErrorIs(t, err, errNotFound)
+	assert.ErrorContains(t, err, "bad status code 404: Resource not found.")
+
+	// POST requests reaching the server aren't retried.
+	assert.Error(t, c.DoJSON("POST", "objects", nil, map[string]string{"name": "object"}, nil))
+	assert.Equal(t, 1, posts)
+
+	invalid := NewClient(context.Background(), "Test API", server.URL+"/api", server.URL+"/identity", Credentials{ClientID: "client-1", Scope: "api.test", Description: "the test credentials"})
+	assert.ErrorContains(t, invalid.DoJSON("GET", "objects/1", nil, nil, nil), "error logging in with the test credentials")
+}
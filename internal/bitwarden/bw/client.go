@@ -4,52 +4,60 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/command"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
 )
 
 type Client interface {
 	CreateAttachment(itemId, filePath string) (*Object, error)
 	CreateObject(Object) (*Object, error)
+	EditItemCollections(itemID string, collectionIDs []string) (*Object, error)
 	EditObject(Object) (*Object, error)
 	GetAttachment(itemId, attachmentId string) ([]byte, error)
 	GetObject(Object) (*Object, error)
 	GetSessionKey() string
 	ListObjects(objType string, options ...ListObjectsOption) ([]Object, error)
 	LoginWithAPIKey(password, clientId, clientSecret string) error
-	LoginWithPassword(username, password string) error
+	LoginWithPassword(username, password string, options ...LoginOption) error
 	Logout() error
 	DeleteAttachment(itemId, attachmentId string) error
 	DeleteObject(Object) error
-	SetServer(string) error
+	SetServer(Environment) error
 	SetSessionKey(string)
+	ShareObject(itemID, organizationID string, collectionIDs []string) (*Object, error)
 	Status() (*Status, error)
 	Sync() error
 	Unlock(password string) error
+	Version() (string, error)
 }
 
 func NewClient(execPath string, opts ...Options) Client {
 	c := &client{
-		execPath: execPath,
+		execPath:    execPath,
+		retryPolicy: retry.DefaultPolicy(),
 	}
 
 	for _, o := range opts {
 		o(c)
 	}
 
-	c.newCommand = command.NewWithRetries(&retryHandler{disableRetryBackoff: c.disableRetryBackoff})
+	c.newCommand = command.NewWithRetries(c.retryPolicy)
+	c.newNonIdempotentCommand = command.NewWithRetries(c.retryPolicy.ForNonIdempotent())
 
 	return c
 }
 
 type client struct {
-	appDataDir          string
-	disableSync         bool
-	disableRetryBackoff bool
-	execPath            string
-	extraCACertsPath    string
-	newCommand          command.NewFn
-	sessionKey          string
+	appDataDir              string
+	disableSync             bool
+	execPath                string
+	extraCACertsPath        string
+	newCommand              command.NewFn
+	newNonIdempotentCommand command.NewFn
+	retryPolicy             retry.Policy
+	sessionKey              string
 }
 
 type Options func(c Client)
@@ -72,9 +80,29 @@ func DisableSync() Options {
 	}
 }
 
+// WithRetryPolicy configures how operations failing with transient errors
+// are retried. It applies to both the CLI and the REST clients.
+func WithRetryPolicy(policy retry.Policy) Options {
+	return func(c Client) {
+		switch v := c.(type) {
+		case *client:
+			policy.DisableBackoff = policy.DisableBackoff || v.retryPolicy.DisableBackoff
+			v.retryPolicy = policy
+		case *restClient:
+			policy.DisableBackoff = policy.DisableBackoff || v.retryPolicy.DisableBackoff
+			v.retryPolicy = policy
+		}
+	}
+}
+
 func DisableRetryBackoff() Options {
 	return func(c Client) {
-		c.(*client).disableRetryBackoff = true
+		switch v := c.(type) {
+		case *client:
+			v.retryPolicy.DisableBackoff = true
+		case *restClient:
+			v.retryPolicy.DisableBackoff = true
+		}
 	}
 }
 
@@ -94,9 +122,9 @@ func (c *client) CreateObject(obj Object) (*Object, error) {
 		args = append(args, "--organizationid", obj.OrganizationID)
 	}
 
-	out, err := c.cmdWithSession(args...).Run()
+	out, err := c.nonIdempotentCmdWithSession(args...).Run()
 	if err != nil {
-		return nil, err
+		return nil, remapError(err)
 	}
 	err = json.Unmarshal(out, &obj)
 	if err != nil {
@@ -109,9 +137,9 @@ func (c *client) CreateObject(obj Object) (*Object, error) {
 }
 
 func (c *client) CreateAttachment(itemId string, filePath string) (*Object, error) {
-	out, err := c.cmdWithSession("create", string(ObjectTypeAttachment), "--itemid", itemId, "--file", filePath).Run()
+	out, err := c.nonIdempotentCmdWithSession("create", string(ObjectTypeAttachment), "--itemid", itemId, "--file", filePath).Run()
 	if err != nil {
-		return nil, err
+		return nil, remapError(err)
 	}
 
 	var obj Object
@@ -125,6 +153,32 @@ func (c *client) CreateAttachment(itemId string, filePath string) (*Object, erro
 	return &obj, nil
 }
 
+// EditItemCollections replaces the collections an item of an organization is
+// assigned to, which editing the item doesn't change.
+func (c *client) EditItemCollections(itemID string, collectionIDs []string) (*Object, error) {
+	collectionsEncoded, err := c.encode(collectionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.cmdWithSession("edit", "item-collections", itemID, collectionsEncoded).Run()
+	if err != nil {
+		return nil, remapError(err)
+	}
+
+	var obj Object
+	err = json.Unmarshal(out, &obj)
+	if err != nil {
+		return nil, newUnmarshallError(err, "edit item collections", out)
+	}
+	err = c.Sync()
+	if err != nil {
+		return nil, fmt.Errorf("error syncing: %v, %v", err, string(out))
+	}
+
+	return &obj, nil
+}
+
 func (c *client) EditObject(obj Object) (*Object, error) {
 	objEncoded, err := c.encode(obj)
 	if err != nil {
@@ -133,7 +187,7 @@ func (c *client) EditObject(obj Object) (*Object, error) {
 
 	out, err := c.cmdWithSession("edit", string(obj.Object), obj.ID, objEncoded).Run()
 	if err != nil {
-		return nil, err
+		return nil, remapError(err)
 	}
 	err = json.Unmarshal(out, &obj)
 	if err != nil {
@@ -147,6 +201,33 @@ func (c *client) EditObject(obj Object) (*Object, error) {
 	return &obj, nil
 }
 
+// ShareObject moves an item of the personal Vault to an organization, which
+// re-encrypts it with the organization's key, and assigns it to collections
+// of the organization.
+func (c *client) ShareObject(itemID, organizationID string, collectionIDs []string) (*Object, error) {
+	collectionsEncoded, err := c.encode(collectionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.nonIdempotentCmdWithSession("share", itemID, organizationID, collectionsEncoded).Run()
+	if err != nil {
+		return nil, remapError(err)
+	}
+
+	var obj Object
+	err = json.Unmarshal(out, &obj)
+	if err != nil {
+		return nil, newUnmarshallError(err, "share object", out)
+	}
+	err = c.Sync()
+	if err != nil {
+		return nil, fmt.Errorf("error syncing: %v, %v", err, string(out))
+	}
+
+	return &obj, nil
+}
+
 func (c *client) GetObject(obj Object) (*Object, error) {
 	args := []string{
 		"get",
@@ -211,10 +292,15 @@ func (c *client) ListObjects(objType string, options ...ListObjectsOption) ([]Ob
 
 // LoginWithPassword logs in using a password and retrieves the session key,
 // allowing authenticated requests using the client.
-func (c *client) LoginWithPassword(username, password string) error {
-	out, err := c.cmd("login", username, "--raw", "--passwordenv", "BW_PASSWORD").AppendEnv([]string{fmt.Sprintf("BW_PASSWORD=%s", password)}).Run()
+func (c *client) LoginWithPassword(username, password string, options ...LoginOption) error {
+	args := []string{"login", username, "--raw", "--passwordenv", "BW_PASSWORD"}
+	for _, applyOption := range options {
+		applyOption(&args)
+	}
+
+	out, err := c.cmd(args...).AppendEnv([]string{fmt.Sprintf("BW_PASSWORD=%s", password)}).Run()
 	if err != nil {
-		return err
+		return remapError(err)
 	}
 	c.sessionKey = string(out)
 	return nil
@@ -225,14 +311,14 @@ func (c *client) LoginWithPassword(username, password string) error {
 func (c *client) LoginWithAPIKey(password, clientId, clientSecret string) error {
 	_, err := c.cmd("login", "--apikey").AppendEnv([]string{fmt.Sprintf("BW_CLIENTID=%s", clientId), fmt.Sprintf("BW_CLIENTSECRET=%s", clientSecret)}).Run()
 	if err != nil {
-		return err
+		return remapError(err)
 	}
 	return c.Unlock(password)
 }
 
 func (c *client) Logout() error {
 	_, err := c.cmd("logout").Run()
-	return err
+	return remapError(err)
 }
 
 func (c *client) DeleteObject(obj Object) error {
@@ -247,23 +333,23 @@ func (c *client) DeleteObject(obj Object) error {
 	}
 
 	_, err := c.cmdWithSession(args...).Run()
-	return err
+	return remapError(err)
 }
 
 func (c *client) DeleteAttachment(itemId, attachmentId string) error {
 	_, err := c.cmdWithSession("delete", string(ObjectTypeAttachment), attachmentId, "--itemid", itemId).Run()
-	return err
+	return remapError(err)
 }
 
-func (c *client) SetServer(server string) error {
-	_, err := c.cmd("config", "server", server).Run()
-	return err
+func (c *client) SetServer(env Environment) error {
+	_, err := c.cmd(env.configServerArgs()...).Run()
+	return remapError(err)
 }
 
 func (c *client) Status() (*Status, error) {
 	out, err := c.cmdWithSession("status").Run()
 	if err != nil {
-		return nil, err
+		return nil, remapError(err)
 	}
 
 	var status Status
@@ -278,7 +364,7 @@ func (c *client) Status() (*Status, error) {
 func (c *client) Unlock(password string) error {
 	out, err := c.cmd("unlock", "--raw", "--passwordenv", "BW_PASSWORD").AppendEnv([]string{fmt.Sprintf("BW_PASSWORD=%s", password)}).Run()
 	if err != nil {
-		return err
+		return remapError(err)
 	}
 
 	c.sessionKey = string(out)
@@ -294,7 +380,16 @@ func (c *client) Sync() error {
 		return nil
 	}
 	_, err := c.cmdWithSession("sync").Run()
-	return err
+	return remapError(err)
+}
+
+// Version returns the version of the Bitwarden CLI.
+func (c *client) Version() (string, error) {
+	out, err := c.cmd("--version").Run()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
 }
 
 func (c *client) cmd(args ...string) command.Command {
@@ -305,6 +400,12 @@ func (c *client) cmdWithSession(args ...string) command.Command {
 	return c.cmd(args...).AppendEnv([]string{fmt.Sprintf("BW_SESSION=%s", c.sessionKey)})
 }
 
+// nonIdempotentCmdWithSession is used for commands which would apply twice
+// when retried after reaching the server, e.g. creating an item.
+func (c *client) nonIdempotentCmdWithSession(args ...string) command.Command {
+	return c.newNonIdempotentCommand(c.execPath, args...).AppendEnv(c.env()).AppendEnv([]string{fmt.Sprintf("BW_SESSION=%s", c.sessionKey)})
+}
+
 func (c *client) env() []string {
 	defaultEnv := []string{
 		fmt.Sprintf("PATH=%s", os.Getenv("PATH")),
@@ -317,8 +418,8 @@ func (c *client) env() []string {
 	return defaultEnv
 }
 
-func (c *client) encode(item Object) (string, error) {
-	newOut, err := json.Marshal(item)
+func (c *client) encode(v interface{}) (string, error) {
+	newOut, err := json.Marshal(v)
 	if err != nil {
 		return "", fmt.Errorf("marshalling error: %v, %v", err, string(newOut))
 	}
@@ -1,6 +1,9 @@
 package bw
 
-import "net/url"
+import (
+	"net/url"
+	"strconv"
+)
 
 type ListObjectsOption func(args *[]string, q *url.Values)
 type ListObjectsOptionGenerator func(id string) ListObjectsOption
@@ -54,3 +57,12 @@ func WithUrl(uri string) ListObjectsOption {
 		}
 	}
 }
+
+type LoginOption func(args *[]string)
+
+// WithTwoFactor provides the code of a two-step login method.
+func WithTwoFactor(method TwoFactorMethod, code string) LoginOption {
+	return func(args *[]string) {
+		*args = append(*args, "--method", strconv.Itoa(int(method)), "--code", code)
+	}
+}
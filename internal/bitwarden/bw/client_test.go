@@ -77,3 +77,38 @@ func TestGetOrgCollection(t *testing.T) {
 		assert.Equal(t, "get org-collection object-id --organizationid org-id", commandsExecuted()[0])
 	}
 }
+
+func TestShareObject(t *testing.T) {
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{
+		"encode":                              `WyJjb2wtMSJdCg==`,
+		"share item-1 org-1 WyJjb2wtMSJdCg==": `{"id": "item-1", "object": "item", "organizationId": "org-1", "collectionIds": ["col-1"]}`,
+		"sync":                                ``,
+	})
+	defer removeMocks(t)
+
+	b := NewClient("dummy")
+	obj, err := b.ShareObject("item-1", "org-1", []string{"col-1"})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "org-1", obj.OrganizationID)
+		assert.Equal(t, []string{"col-1"}, obj.CollectionIds)
+	}
+	assert.Equal(t, []string{`["col-1"]:/:encode`, "share item-1 org-1 WyJjb2wtMSJdCg==", "sync"}, commandsExecuted())
+}
+
+func TestEditItemCollections(t *testing.T) {
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{
+		"encode": `WyJjb2wtMSIsImNvbC0yIl0K`,
+		"edit item-collections item-1 WyJjb2wtMSIsImNvbC0yIl0K": `{"id": "item-1", "object": "item", "organizationId": "org-1", "collectionIds": ["col-1", "col-2"]}`,
+		"sync": ``,
+	})
+	defer removeMocks(t)
+
+	b := NewClient("dummy")
+	obj, err := b.EditItemCollections("item-1", []string{"col-1", "col-2"})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"col-1", "col-2"}, obj.CollectionIds)
+	}
+	assert.Equal(t, []string{`["col-1","col-2"]:/:encode`, "edit item-collections item-1 WyJjb2wtMSIsImNvbC0yIl0K", "sync"}, commandsExecuted())
+}
@@ -0,0 +1,89 @@
+package bw
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	RegionEU = "eu"
+	RegionUS = "us"
+)
+
+var regionServerURLs = map[string]string{
+	RegionEU: "https://vault.bitwarden.eu",
+	RegionUS: DefaultBitwardenServerURL,
+}
+
+// RegionServerURL returns the URL of the Bitwarden cloud server of a region.
+func RegionServerURL(region string) (string, error) {
+	serverURL, ok := regionServerURLs[region]
+	if !ok {
+		return "", fmt.Errorf("unknown Bitwarden region '%s'", region)
+	}
+	return serverURL, nil
+}
+
+// Environment holds the URLs of the services of a Bitwarden deployment.
+// Services without an explicit URL are reached through the base server URL,
+// like the Bitwarden clients do.
+type Environment struct {
+	Server        string
+	WebVault      string
+	API           string
+	Identity      string
+	Icons         string
+	Notifications string
+	KeyConnector  string
+}
+
+// APIURL returns the URL of the API service. Bitwarden's cloud offering hosts
+// it on a dedicated domain, while self-hosted installations serve it under a
+// sub-path.
+func (e Environment) APIURL() string {
+	if len(e.API) > 0 {
+		return trimSlashSuffix(e.API)
+	}
+	return e.serviceURL("api")
+}
+
+// IdentityURL returns the URL of the identity service.
+func (e Environment) IdentityURL() string {
+	if len(e.Identity) > 0 {
+		return trimSlashSuffix(e.Identity)
+	}
+	return e.serviceURL("identity")
+}
+
+func (e Environment) serviceURL(service string) string {
+	serverURL := trimSlashSuffix(e.Server)
+	if strings.HasPrefix(serverURL, "https://vault.bitwarden.") {
+		domain := strings.TrimPrefix(serverURL, "https://vault.")
+		return fmt.Sprintf("https://%s.%s", service, domain)
+	}
+	return fmt.Sprintf("%s/%s", serverURL, service)
+}
+
+// configServerArgs returns the arguments of 'bw config server' applying the
+// environment.
+func (e Environment) configServerArgs() []string {
+	args := []string{"config", "server", e.Server}
+
+	flags := []struct {
+		name  string
+		value string
+	}{
+		{"--web-vault", e.WebVault},
+		{"--api", e.API},
+		{"--identity", e.Identity},
+		{"--icons", e.Icons},
+		{"--notifications", e.Notifications},
+		{"--key-connector", e.KeyConnector},
+	}
+	for _, flag := range flags {
+		if len(flag.value) > 0 {
+			args = append(args, flag.name, flag.value)
+		}
+	}
+	return args
+}
@@ -0,0 +1,50 @@
+package bw
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironmentServiceURLs(t *testing.T) {
+	testCases := []struct {
+		env         Environment
+		apiURL      string
+		identityURL string
+	}{
+		{env: Environment{Server: "https://vault.bitwarden.com"}, apiURL: "https://api.bitwarden.com", identityURL: "https://identity.bitwarden.com"},
+		{env: Environment{Server: "https://vault.bitwarden.eu/"}, apiURL: "https://api.bitwarden.eu", identityURL: "https://identity.bitwarden.eu"},
+		{env: Environment{Server: "https://bitwarden.example.com/"}, apiURL: "https://bitwarden.example.com/api", identityURL: "https://bitwarden.example.com/identity"},
+		{env: Environment{Server: "https://bitwarden.example.com", API: "https://api.example.com/", Identity: "https://sso.example.com"}, apiURL: "https://api.example.com", identityURL: "https://sso.example.com"},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.apiURL, test.env.APIURL())
+		assert.Equal(t, test.identityURL, test.env.IdentityURL())
+	}
+}
+
+func TestEnvironmentConfigServerArgs(t *testing.T) {
+	env := Environment{
+		Server:        "https://bitwarden.example.com",
+		API:           "https://api.example.com",
+		Identity:      "https://identity.example.com",
+		Notifications: "https://notifications.example.com",
+	}
+
+	assert.Equal(t, []string{
+		"config", "server", "https://bitwarden.example.com",
+		"--api", "https://api.example.com",
+		"--identity", "https://identity.example.com",
+		"--notifications", "https://notifications.example.com",
+	}, env.configServerArgs())
+}
+
+func TestRegionServerURL(t *testing.T) {
+	serverURL, err := RegionServerURL(RegionEU)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://vault.bitwarden.eu", serverURL)
+
+	_, err = RegionServerURL("ap")
+	assert.EqualError(t, err, "unknown Bitwarden region 'ap'")
+}
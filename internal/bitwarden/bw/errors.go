@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/command"
 )
@@ -12,9 +13,47 @@ var (
 	ErrObjectNotFound     = errors.New("object not found")
 	ErrAttachmentNotFound = errors.New("attachment not found")
 
+	ErrVaultLocked           = errors.New("vault is locked")
+	ErrNotLoggedIn           = errors.New("not logged in")
+	ErrInvalidMasterPassword = errors.New("invalid master password")
+	ErrTwoFactorRequired     = errors.New("two-step login required")
+	ErrTwoFactorInvalid      = errors.New("invalid two-step login code")
+	ErrPermissionDenied      = errors.New("permission denied")
+	ErrOrganizationDisabled  = errors.New("organization is disabled")
+
 	attachmentNotFoundRegexp = regexp.MustCompile(`^Attachment .* was not found.$`)
+
+	// knownErrors maps messages of the Bitwarden CLI and of 'bw serve' onto
+	// the errors above.
+	knownErrors = []struct {
+		pattern *regexp.Regexp
+		kind    error
+	}{
+		{regexp.MustCompile(`(?i)vault is locked`), ErrVaultLocked},
+		{regexp.MustCompile(`(?i)you are not logged in`), ErrNotLoggedIn},
+		{regexp.MustCompile(`(?i)invalid master password|username or password is incorrect`), ErrInvalidMasterPassword},
+		{regexp.MustCompile(`(?i)two-step token is invalid|invalid two-step|invalid two-factor`), ErrTwoFactorInvalid},
+		{regexp.MustCompile(`(?i)code is required|no provider selected|two-step login is required`), ErrTwoFactorRequired},
+		{regexp.MustCompile(`(?i)you do not have permission|you don't have permission|you do not have access`), ErrPermissionDenied},
+		{regexp.MustCompile(`(?i)organization (is )?disabled|organization has been disabled`), ErrOrganizationDisabled},
+	}
 )
 
+// Error is an error reported by Bitwarden which has been recognized as one of
+// the known errors above, while keeping the original message.
+type Error struct {
+	Kind    error
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
 func newUnmarshallError(err error, cmd string, out []byte) error {
 	return fmt.Errorf("unable to parse result of '%s' command: %v, output: %v", cmd, err, string(out))
 }
@@ -22,20 +61,43 @@ func newUnmarshallError(err error, cmd string, out []byte) error {
 func remapError(err error) error {
 	v, ok := err.(*command.CommandError)
 	if ok {
-		switch {
-		case isObjectNotFoundError(v):
-			return ErrObjectNotFound
-		case isAttachmentNotFoundError(v):
-			return ErrAttachmentNotFound
+		if known := errorFromMessage(v.Stderr()); known != nil {
+			return known
 		}
 	}
 	return err
 }
 
-func isAttachmentNotFoundError(err *command.CommandError) bool {
-	return attachmentNotFoundRegexp.Match([]byte(err.Stderr()))
+// remapMessage returns the error corresponding to a message returned by
+// 'bw serve'.
+func remapMessage(msg string) error {
+	if known := errorFromMessage(msg); known != nil {
+		return known
+	}
+	return errors.New(msg)
+}
+
+func errorFromMessage(msg string) error {
+	msg = strings.TrimSpace(msg)
+	switch {
+	case isObjectNotFoundError(msg):
+		return ErrObjectNotFound
+	case isAttachmentNotFoundError(msg):
+		return ErrAttachmentNotFound
+	}
+
+	for _, known := range knownErrors {
+		if known.pattern.MatchString(msg) {
+			return &Error{Kind: known.kind, Message: msg}
+		}
+	}
+	return nil
+}
+
+func isAttachmentNotFoundError(msg string) bool {
+	return attachmentNotFoundRegexp.MatchString(msg)
 }
 
-func isObjectNotFoundError(err *command.CommandError) bool {
-	return err.Stderr() == "Not found."
+func isObjectNotFoundError(msg string) bool {
+	return msg == "Not found."
 }
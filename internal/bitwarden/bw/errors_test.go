@@ -0,0 +1,51 @@
+package bw
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/command"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemapError(t *testing.T) {
+	testCases := []struct {
+		stderr   string
+		expected error
+	}{
+		{stderr: "Not found.", expected: ErrObjectNotFound},
+		{stderr: "Attachment abc was not found.", expected: ErrAttachmentNotFound},
+		{stderr: "Vault is locked.", expected: ErrVaultLocked},
+		{stderr: "You are not logged in.", expected: ErrNotLoggedIn},
+		{stderr: "Invalid master password.\n", expected: ErrInvalidMasterPassword},
+		{stderr: "Username or password is incorrect. Try again.", expected: ErrInvalidMasterPassword},
+		{stderr: "Two-step login is required.", expected: ErrTwoFactorRequired},
+		{stderr: "Code is required.", expected: ErrTwoFactorRequired},
+		{stderr: "No provider selected.", expected: ErrTwoFactorRequired},
+		{stderr: "You do not have permission to edit this.", expected: ErrPermissionDenied},
+		{stderr: "This organization is disabled.", expected: ErrOrganizationDisabled},
+	}
+
+	for _, test := range testCases {
+		err := remapError(command.NewError(errors.New("exit status 1"), []string{"get"}, "", test.stderr))
+		assert.ErrorIs(t, err, test.expected, test.stderr)
+	}
+
+	unknown := command.NewError(errors.New("exit status 1"), []string{"get"}, "", "Something else.")
+	assert.Equal(t, unknown, remapError(unknown))
+}
+
+func TestRemapErrorDistinguishesInvalidTwoFactorCode(t *testing.T) {
+	err := remapError(command.NewError(errors.New("exit status 1"), []string{"login"}, "", "Two-step token is invalid. Try again."))
+
+	assert.ErrorIs(t, err, ErrTwoFactorInvalid)
+	assert.NotErrorIs(t, err, ErrTwoFactorRequired)
+}
+
+func TestRemapMessageKeepsOriginalMessage(t *testing.T) {
+	err := remapMessage("Vault is locked.")
+
+	assert.ErrorIs(t, err, ErrVaultLocked)
+	assert.EqualError(t, err, "Vault is locked.")
+	assert.EqualError(t, remapMessage("Something else."), "Something else.")
+}
@@ -7,6 +7,16 @@ import (
 
 type ItemType int
 
+// TwoFactorMethod identifies a two-step login provider, as expected by the
+// '--method' flag of 'bw login'.
+type TwoFactorMethod int
+
+const (
+	TwoFactorMethodAuthenticator TwoFactorMethod = 0
+	TwoFactorMethodEmail         TwoFactorMethod = 1
+	TwoFactorMethodYubiKey       TwoFactorMethod = 3
+)
+
 const (
 	ItemTypeLogin      ItemType = 1
 	ItemTypeSecureNote ItemType = 2
@@ -83,6 +93,58 @@ const (
 	URIMatchNever      URIMatch = 5
 )
 
+// URIMatchDefaultName is the name of the absence of URI match detection mode,
+// i.e. of the default mode of Bitwarden clients.
+const URIMatchDefaultName = "default"
+
+// uriMatchNames are the names of URI match detection modes, as used in
+// Terraform configurations.
+var uriMatchNames = map[URIMatch]string{
+	URIMatchBaseDomain: "base_domain",
+	URIMatchHost:       "host",
+	URIMatchStartWith:  "start_with",
+	URIMatchExact:      "exact",
+	URIMatchRegExp:     "regexp",
+	URIMatchNever:      "never",
+}
+
+// URIMatchNames returns the names of all URI match detection modes, starting
+// with the default one.
+func URIMatchNames() []string {
+	names := []string{URIMatchDefaultName}
+	for match := URIMatchBaseDomain; match <= URIMatchNever; match++ {
+		names = append(names, uriMatchNames[match])
+	}
+	return names
+}
+
+// URIMatchName returns the name of a URI match detection mode, and false if
+// the mode is unknown. A nil mode is the default one.
+func URIMatchName(match *URIMatch) (string, bool) {
+	if match == nil {
+		return URIMatchDefaultName, true
+	}
+	name, ok := uriMatchNames[*match]
+	if !ok {
+		return URIMatchDefaultName, false
+	}
+	return name, true
+}
+
+// URIMatchFromName returns the URI match detection mode of a name, nil for
+// the default one, and false if the name is unknown.
+func URIMatchFromName(name string) (*URIMatch, bool) {
+	if name == URIMatchDefaultName {
+		return nil, true
+	}
+	for match, matchName := range uriMatchNames {
+		if matchName == name {
+			return &match, true
+		}
+	}
+	return nil, false
+}
+
 type LoginURI struct {
 	Match *URIMatch `json:"match,omitempty"`
 	URI   string    `json:"uri,omitempty"`
@@ -93,7 +155,8 @@ type SecureNote struct {
 }
 
 type RESTSuccess struct {
-	Success bool `json:"success"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
 }
 
 type RESTWrapper[T any] struct {
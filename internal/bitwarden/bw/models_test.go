@@ -92,3 +92,24 @@ func TestVaultOfUser(t *testing.T) {
 	}
 
 }
+
+func TestURIMatchNames(t *testing.T) {
+	assert.Equal(t, []string{"default", "base_domain", "host", "start_with", "exact", "regexp", "never"}, URIMatchNames())
+
+	for _, name := range URIMatchNames() {
+		match, ok := URIMatchFromName(name)
+		assert.True(t, ok, name)
+
+		roundTrip, ok := URIMatchName(match)
+		assert.True(t, ok, name)
+		assert.Equal(t, name, roundTrip)
+	}
+
+	_, ok := URIMatchFromName("unknown")
+	assert.False(t, ok)
+
+	unknown := URIMatch(42)
+	name, ok := URIMatchName(&unknown)
+	assert.False(t, ok)
+	assert.Equal(t, URIMatchDefaultName, name)
+}
@@ -6,13 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/redact"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -33,31 +34,49 @@ func (t LoggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error
 }
 
 type restClient struct {
-	ctx          context.Context
-	retryHandler *retryHandler
+	ctx         context.Context
+	retryPolicy retry.Policy
 
 	client   *http.Client
 	endpoint string
 }
 
-func retry[T any](r *restClient, fn func() (T, error)) (T, error) {
-	attempts := 0
-	for {
-		attempts = attempts + 1
-		out, err := fn()
-		if err == nil || !r.retryHandler.IsRetryable(err, attempts) {
-			return out, err
-		}
+// do sends the request, turning throttling and server-side errors into
+// errors the retry policy recognizes.
+func (r *restClient) do(req *http.Request) (*http.Response, error) {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
 
-		r.retryHandler.Backoff(attempts)
-		log.Printf("[ERROR] Retrying command after error: %v\n", err)
+	err = retry.CheckResponse(resp)
+	if err != nil {
+		return nil, err
 	}
+	return resp, nil
+}
+
+func (r *restClient) get(url string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.do(req)
+}
+
+func (r *restClient) post(url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest("POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return r.do(req)
 }
 
 func (r *restClient) CreateAttachment(itemId, filePath string) (*Object, error) {
 	tflog.Debug(r.ctx, "Creating attachment", map[string]any{"itemId": itemId})
 
-	return retry(r, func() (*Object, error) {
+	return retry.Do(r.retryPolicy.ForNonIdempotent(), func() (*Object, error) {
 		// Prepare file for upload
 		var (
 			buf = new(bytes.Buffer)
@@ -94,14 +113,14 @@ func (r *restClient) CreateAttachment(itemId, filePath string) (*Object, error)
 		q.Set("itemid", itemId)
 		u.RawQuery = q.Encode()
 
-		resp, err := r.client.Post(u.String(), w.FormDataContentType(), buf)
+		resp, err := r.post(u.String(), w.FormDataContentType(), buf)
 		if err != nil {
 			return nil, err
 		}
 
 		o, sErr := readResponse[Object](r.ctx, resp)
 		if len(sErr) > 0 {
-			return nil, fmt.Errorf(sErr)
+			return nil, remapMessage(sErr)
 		}
 
 		return o, nil
@@ -111,7 +130,7 @@ func (r *restClient) CreateAttachment(itemId, filePath string) (*Object, error)
 func (r *restClient) CreateObject(object Object) (*Object, error) {
 	tflog.Debug(r.ctx, "Creating object", map[string]any{"itemId": object.ID})
 
-	return retry(r, func() (*Object, error) {
+	return retry.Do(r.retryPolicy.ForNonIdempotent(), func() (*Object, error) {
 		requestData, err := json.Marshal(object)
 		if err != nil {
 			return nil, err
@@ -123,14 +142,49 @@ func (r *restClient) CreateObject(object Object) (*Object, error) {
 		}
 
 		u = u.JoinPath("object", "item")
-		resp, err := r.client.Post(u.String(), "application/json", bytes.NewBuffer(requestData))
+		resp, err := r.post(u.String(), "application/json", bytes.NewBuffer(requestData))
+		if err != nil {
+			return nil, err
+		}
+
+		o, sErr := readResponse[Object](r.ctx, resp)
+		if len(sErr) > 0 {
+			return nil, remapMessage(sErr)
+		}
+
+		return o, nil
+	})
+}
+
+func (r *restClient) EditItemCollections(itemID string, collectionIDs []string) (*Object, error) {
+	tflog.Debug(r.ctx, "Editing item collections", map[string]any{"itemId": itemID})
+
+	return retry.Do(r.retryPolicy, func() (*Object, error) {
+		requestData, err := json.Marshal(collectionIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := url.Parse(r.endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		u = u.JoinPath("object", "item-collections", itemID)
+		request, err := http.NewRequest("PUT", u.String(), bytes.NewBuffer(requestData))
+		if err != nil {
+			return nil, err
+		}
+
+		request.Header.Set("Content-Type", "application/json")
+		resp, err := r.do(request)
 		if err != nil {
 			return nil, err
 		}
 
 		o, sErr := readResponse[Object](r.ctx, resp)
 		if len(sErr) > 0 {
-			return nil, fmt.Errorf(sErr)
+			return nil, remapMessage(sErr)
 		}
 
 		return o, nil
@@ -140,7 +194,7 @@ func (r *restClient) CreateObject(object Object) (*Object, error) {
 func (r *restClient) EditObject(object Object) (*Object, error) {
 	tflog.Debug(r.ctx, "Editing object", map[string]any{"itemId": object.ID})
 
-	return retry(r, func() (*Object, error) {
+	return retry.Do(r.retryPolicy, func() (*Object, error) {
 		requestData, err := json.Marshal(object)
 		if err != nil {
 			return nil, err
@@ -158,14 +212,14 @@ func (r *restClient) EditObject(object Object) (*Object, error) {
 		}
 
 		request.Header.Set("Content-Type", "application/json")
-		resp, err := r.client.Do(request)
+		resp, err := r.do(request)
 		if err != nil {
 			return nil, err
 		}
 
 		o, sErr := readResponse[Object](r.ctx, resp)
 		if len(sErr) > 0 {
-			return nil, fmt.Errorf(sErr)
+			return nil, remapMessage(sErr)
 		}
 
 		return o, nil
@@ -175,7 +229,7 @@ func (r *restClient) EditObject(object Object) (*Object, error) {
 func (r *restClient) GetAttachment(itemId, attachmentId string) ([]byte, error) {
 	tflog.Debug(r.ctx, "Getting attachement", map[string]any{"itemId": itemId, "attachement": attachmentId})
 
-	return retry(r, func() ([]byte, error) {
+	return retry.Do(r.retryPolicy, func() ([]byte, error) {
 		u, err := url.Parse(r.endpoint)
 		if err != nil {
 			return nil, err
@@ -186,7 +240,7 @@ func (r *restClient) GetAttachment(itemId, attachmentId string) ([]byte, error)
 		q.Set("itemid", itemId)
 		u.RawQuery = q.Encode()
 
-		resp, err := r.client.Get(u.String())
+		resp, err := r.get(u.String())
 		if err != nil {
 			return nil, err
 		}
@@ -207,25 +261,21 @@ func (r *restClient) GetAttachment(itemId, attachmentId string) ([]byte, error)
 func (r *restClient) GetObject(object Object) (*Object, error) {
 	tflog.Debug(r.ctx, "Getting object", map[string]any{"itemId": object.ID})
 
-	return retry(r, func() (*Object, error) {
+	return retry.Do(r.retryPolicy, func() (*Object, error) {
 		u, err := url.Parse(r.endpoint)
 		if err != nil {
 			return nil, err
 		}
 
 		u = u.JoinPath("object", "item", object.ID)
-		resp, err := r.client.Get(u.String())
+		resp, err := r.get(u.String())
 		if err != nil {
 			return nil, err
 		}
 
 		o, sErr := readResponse[Object](r.ctx, resp)
 		if len(sErr) > 0 {
-			if sErr == "Not found." {
-				return nil, ErrObjectNotFound
-			}
-
-			return nil, fmt.Errorf(sErr)
+			return nil, remapMessage(sErr)
 		}
 
 		return o, nil
@@ -239,7 +289,7 @@ func (r *restClient) GetSessionKey() string {
 func (r *restClient) ListObjects(objType string, options ...ListObjectsOption) ([]Object, error) {
 	tflog.Debug(r.ctx, "List objects", map[string]any{"type": objType})
 
-	return retry(r, func() ([]Object, error) {
+	return retry.Do(r.retryPolicy, func() ([]Object, error) {
 		u, err := url.Parse(r.endpoint)
 		if err != nil {
 			return nil, err
@@ -255,14 +305,14 @@ func (r *restClient) ListObjects(objType string, options ...ListObjectsOption) (
 
 		u.RawQuery = q.Encode()
 
-		resp, err := r.client.Get(u.String())
+		resp, err := r.get(u.String())
 		if err != nil {
 			return nil, err
 		}
 
 		l, sErr := readArrayResponse[Object](r.ctx, resp)
 		if len(sErr) > 0 {
-			return nil, fmt.Errorf(sErr)
+			return nil, remapMessage(sErr)
 		}
 
 		return l, nil
@@ -273,7 +323,7 @@ func (r *restClient) LoginWithAPIKey(password, clientId, clientSecret string) er
 	return fmt.Errorf("rest client doesn't support login")
 }
 
-func (r *restClient) LoginWithPassword(username, password string) error {
+func (r *restClient) LoginWithPassword(username, password string, options ...LoginOption) error {
 	return fmt.Errorf("rest client doesn't support login")
 }
 
@@ -284,78 +334,113 @@ func (r *restClient) Logout() error {
 func (r *restClient) DeleteAttachment(itemId, attachmentId string) error {
 	tflog.Debug(r.ctx, "Delete attachement", map[string]any{"itemId": itemId, "attachementId": attachmentId})
 
-	u, err := url.Parse(r.endpoint)
-	if err != nil {
-		return err
-	}
+	_, err := retry.Do(r.retryPolicy, func() (any, error) {
+		u, err := url.Parse(r.endpoint)
+		if err != nil {
+			return nil, err
+		}
 
-	u = u.JoinPath("object", "attachment", attachmentId)
-	q := u.Query()
-	q.Set("itemid", itemId)
-	u.RawQuery = q.Encode()
+		u = u.JoinPath("object", "attachment", attachmentId)
+		q := u.Query()
+		q.Set("itemid", itemId)
+		u.RawQuery = q.Encode()
 
-	req, err := http.NewRequest("DELETE", u.String(), nil)
-	if err != nil {
-		return err
-	}
+		req, err := http.NewRequest("DELETE", u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return err
-	}
+		resp, err := r.do(req)
+		if err != nil {
+			return nil, err
+		}
 
-	return readBooleanResponse(resp)
+		return nil, readBooleanResponse(resp)
+	})
+	return err
 }
 
 func (r *restClient) DeleteObject(object Object) error {
 	tflog.Debug(r.ctx, "Deleting object", map[string]any{"itemId": object.ID})
 
-	u, err := url.Parse(r.endpoint)
-	if err != nil {
-		return err
-	}
+	_, err := retry.Do(r.retryPolicy, func() (any, error) {
+		u, err := url.Parse(r.endpoint)
+		if err != nil {
+			return nil, err
+		}
 
-	u = u.JoinPath("object", "item", object.ID)
+		u = u.JoinPath("object", "item", object.ID)
 
-	req, err := http.NewRequest("DELETE", u.String(), nil)
-	if err != nil {
-		return err
-	}
+		req, err := http.NewRequest("DELETE", u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return err
-	}
+		resp, err := r.do(req)
+		if err != nil {
+			return nil, err
+		}
 
-	return readBooleanResponse(resp)
+		return nil, readBooleanResponse(resp)
+	})
+	return err
 }
 
-func (r *restClient) SetServer(s string) error {
-	return fmt.Errorf("rest client doesn't support switching servers")
+func (r *restClient) SetServer(Environment) error {
+	return fmt.Errorf("rest client doesn't support switching servers, the environment must be configured on the 'bw serve' instance")
 }
 
 func (r *restClient) SetSessionKey(s string) {
 
 }
 
+func (r *restClient) ShareObject(itemID, organizationID string, collectionIDs []string) (*Object, error) {
+	tflog.Debug(r.ctx, "Sharing object", map[string]any{"itemId": itemID, "organizationId": organizationID})
+
+	return retry.Do(r.retryPolicy.ForNonIdempotent(), func() (*Object, error) {
+		requestData, err := json.Marshal(collectionIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := url.Parse(r.endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		u = u.JoinPath("move", itemID, organizationID)
+		resp, err := r.post(u.String(), "application/json", bytes.NewBuffer(requestData))
+		if err != nil {
+			return nil, err
+		}
+
+		o, sErr := readResponse[Object](r.ctx, resp)
+		if len(sErr) > 0 {
+			return nil, remapMessage(sErr)
+		}
+
+		return o, nil
+	})
+}
+
 func (r *restClient) Status() (*Status, error) {
 	tflog.Debug(r.ctx, "Getting status")
 
-	return retry(r, func() (*Status, error) {
+	return retry.Do(r.retryPolicy, func() (*Status, error) {
 		u, err := url.Parse(r.endpoint)
 		if err != nil {
 			return nil, err
 		}
 
 		u = u.JoinPath("status")
-		resp, err := r.client.Get(u.String())
+		resp, err := r.get(u.String())
 		if err != nil {
 			return nil, err
 		}
 
 		re, sErr := readResponse[RESTStatus](r.ctx, resp)
 		if len(sErr) > 0 {
-			return nil, fmt.Errorf(sErr)
+			return nil, remapMessage(sErr)
 		}
 
 		return &re.Template, nil
@@ -365,64 +450,62 @@ func (r *restClient) Status() (*Status, error) {
 func (r *restClient) Sync() error {
 	tflog.Debug(r.ctx, "Sync vault")
 
-	u, err := url.Parse(r.endpoint)
-	if err != nil {
-		return err
-	}
-
-	u = u.JoinPath("sync")
-
-	req, err := http.NewRequest("POST", u.String(), nil)
-	if err != nil {
-		return err
-	}
+	_, err := retry.Do(r.retryPolicy, func() (any, error) {
+		u, err := url.Parse(r.endpoint)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return err
-	}
+		u = u.JoinPath("sync")
+		resp, err := r.post(u.String(), "application/json", nil)
+		if err != nil {
+			return nil, err
+		}
 
-	_, sErr := readResponse[RESTStatus](r.ctx, resp)
-	if len(sErr) > 0 {
-		return fmt.Errorf(sErr)
-	}
+		_, sErr := readResponse[RESTStatus](r.ctx, resp)
+		if len(sErr) > 0 {
+			return nil, remapMessage(sErr)
+		}
 
-	return nil
+		return nil, nil
+	})
+	return err
 }
 
 func (r *restClient) Unlock(password string) error {
 	tflog.Debug(r.ctx, "Unlock vault")
 
-	rp := &RESTUnlock{Password: password}
+	_, err := retry.Do(r.retryPolicy, func() (any, error) {
+		rp := &RESTUnlock{Password: password}
 
-	requestData, err := json.Marshal(rp)
-	if err != nil {
-		return err
-	}
+		requestData, err := json.Marshal(rp)
+		if err != nil {
+			return nil, err
+		}
 
-	u, err := url.Parse(r.endpoint)
-	if err != nil {
-		return err
-	}
+		u, err := url.Parse(r.endpoint)
+		if err != nil {
+			return nil, err
+		}
 
-	u = u.JoinPath("unlock")
-	request, err := http.NewRequest("POST", u.String(), bytes.NewBuffer(requestData))
-	if err != nil {
-		return err
-	}
+		u = u.JoinPath("unlock")
+		resp, err := r.post(u.String(), "application/json", bytes.NewBuffer(requestData))
+		if err != nil {
+			return nil, err
+		}
 
-	request.Header.Set("Content-Type", "application/json")
-	resp, err := r.client.Do(request)
-	if err != nil {
-		return err
-	}
+		_, sErr := readResponse[RESTMessageResult](r.ctx, resp)
+		if len(sErr) > 0 {
+			return nil, remapMessage(sErr)
+		}
 
-	_, sErr := readResponse[RESTMessageResult](r.ctx, resp)
-	if len(sErr) > 0 {
-		return fmt.Errorf(sErr)
-	}
+		return nil, nil
+	})
+	return err
+}
 
-	return nil
+func (r *restClient) Version() (string, error) {
+	return "", fmt.Errorf("rest client doesn't support retrieving the CLI version")
 }
 
 func readResponse[T any](ctx context.Context, resp *http.Response) (*T, string) {
@@ -432,7 +515,7 @@ func readResponse[T any](ctx context.Context, resp *http.Response) (*T, string)
 		return nil, err.Error()
 	}
 
-	tflog.Debug(ctx, "Response from BW", map[string]any{"raw": string(respData)})
+	tflog.Debug(ctx, "Response from BW", map[string]any{"raw": redact.JSON(respData)})
 
 	err = json.Unmarshal(respData, &respObj)
 	if err != nil {
@@ -453,7 +536,7 @@ func readArrayResponse[T any](ctx context.Context, resp *http.Response) ([]T, st
 		return nil, err.Error()
 	}
 
-	tflog.Debug(ctx, "Response from BW", map[string]any{"raw": string(respData)})
+	tflog.Debug(ctx, "Response from BW", map[string]any{"raw": redact.JSON(respData)})
 
 	err = json.Unmarshal(respData, &respObj)
 	if err != nil {
@@ -480,20 +563,28 @@ func readBooleanResponse(resp *http.Response) error {
 	}
 
 	if !respObj.Success {
+		if len(respObj.Message) > 0 {
+			return remapMessage(respObj.Message)
+		}
 		return fmt.Errorf("response was not successful")
 	}
 
 	return nil
 }
 
-func NewRestClient(ctx context.Context, endpoint string) Client {
+func NewRestClient(ctx context.Context, endpoint string, opts ...Options) Client {
 	rt := LoggingRoundTripper{ctx: ctx}
 
-	return &restClient{
-		ctx:          ctx,
-		retryHandler: &retryHandler{},
+	c := &restClient{
+		ctx:         ctx,
+		retryPolicy: retry.DefaultPolicy(),
 
 		client:   &http.Client{Transport: rt},
 		endpoint: endpoint,
 	}
+
+	for _, o := range opts {
+		o(c)
+	}
+	return c
 }
@@ -0,0 +1,145 @@
+package bw
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestClientRetriesOnServerErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = calls + 1
+		if calls < 3 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/object/item/item-1", r.URL.Path)
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(context.Background(), server.URL, DisableRetryBackoff())
+	err := client.DeleteObject(Object{ID: "item-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRestClientGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = calls + 1
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	policy := retry.DefaultPolicy()
+	policy.MaxAttempts = 2
+	client := NewRestClient(context.Background(), server.URL, WithRetryPolicy(policy), DisableRetryBackoff())
+	err := client.Sync()
+
+	assert.ErrorContains(t, err, "bad status code: 502")
+	assert.Equal(t, 2, calls)
+}
+
+func TestRestClientOnlyRetriesCreationsWhenUnsent(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = calls + 1
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRestClient(context.Background(), server.URL, DisableRetryBackoff())
+	_, err := client.CreateObject(Object{Object: ObjectTypeItem, Name: "item-1"})
+
+	assert.ErrorContains(t, err, "bad status code: 502")
+	assert.Equal(t, 2, calls)
+}
+
+func TestRestClientRemapsErrorMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success": false, "message": "Vault is locked."}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(context.Background(), server.URL)
+
+	_, err := client.GetObject(Object{ID: "item-1"})
+	assert.ErrorIs(t, err, ErrVaultLocked)
+
+	err = client.DeleteObject(Object{ID: "item-1"})
+	assert.ErrorIs(t, err, ErrVaultLocked)
+}
+
+func TestRestClientRedactsSecretsFromLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success": true, "data": {"id": "item-1", "object": "item", "notes": "item-notes", "login": {"password": "item-password", "totp": "item-totp"}, "fields": [{"name": "api-key", "value": "item-field", "type": 1}]}}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+
+	client := NewRestClient(ctx, server.URL)
+	obj, err := client.GetObject(Object{ID: "item-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "item-password", obj.Login.Password)
+	assert.Contains(t, logs.String(), "item-1")
+	for _, secret := range []string{"item-notes", "item-password", "item-totp", "item-field"} {
+		assert.NotContains(t, logs.String(), secret)
+	}
+}
+
+func TestRestClientShareObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/move/item-1/org-1", r.URL.Path)
+		assert.JSONEq(t, `["col-1"]`, string(body))
+		w.Write([]byte(`{"success": true, "data": {"id": "item-1", "organizationId": "org-1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(context.Background(), server.URL, DisableRetryBackoff())
+	obj, err := client.ShareObject("item-1", "org-1", []string{"col-1"})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "org-1", obj.OrganizationID)
+	}
+}
+
+func TestRestClientEditItemCollections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/object/item-collections/item-1", r.URL.Path)
+		assert.JSONEq(t, `["col-1"]`, string(body))
+		w.Write([]byte(`{"success": true, "data": {"id": "item-1", "collectionIds": ["col-1"]}}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(context.Background(), server.URL, DisableRetryBackoff())
+	obj, err := client.EditItemCollections("item-1", []string{"col-1"})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"col-1"}, obj.CollectionIds)
+	}
+}
@@ -0,0 +1,98 @@
+package bws
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto/symmetrickey"
+	"golang.org/x/crypto/hkdf"
+)
+
+// AccessToken is a machine account access token, in the format
+// '0.<client_id>.<client_secret>:<encryption_key>'.
+type AccessToken struct {
+	ClientID      string
+	ClientSecret  string
+	EncryptionKey []byte
+}
+
+func ParseAccessToken(token string) (*AccessToken, error) {
+	credentials, encodedKey, found := strings.Cut(token, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid access token: missing encryption key")
+	}
+
+	parts := strings.Split(credentials, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid access token: malformed credentials")
+	}
+	if parts[0] != "0" {
+		return nil, fmt.Errorf("invalid access token: unsupported version '%s'", parts[0])
+	}
+
+	encryptionKey, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access token: %w", err)
+	}
+	if len(encryptionKey) != 16 {
+		return nil, fmt.Errorf("invalid access token: encryption key has %d bytes, expected 16", len(encryptionKey))
+	}
+
+	return &AccessToken{
+		ClientID:      parts[1],
+		ClientSecret:  parts[2],
+		EncryptionKey: encryptionKey,
+	}, nil
+}
+
+// String returns the access token in the format expected by Bitwarden
+// clients.
+func (t *AccessToken) String() string {
+	return fmt.Sprintf("0.%s.%s:%s", t.ClientID, t.ClientSecret, base64.StdEncoding.EncodeToString(t.EncryptionKey))
+}
+
+// payloadKey returns the key protecting the payload of token responses. It
+// is derived from the encryption key of the access token, the same way as the
+// Bitwarden SDK does for shareable keys.
+func (t *AccessToken) payloadKey() (*symmetrickey.Key, error) {
+	mac := hmac.New(sha256.New, []byte("bitwarden-accesstoken"))
+	mac.Write(t.EncryptionKey)
+	prk := mac.Sum(nil)
+
+	rawKey := make([]byte, 64)
+	_, err := hkdf.Expand(sha256.New, prk, []byte("sm-access-token")).Read(rawKey)
+	if err != nil {
+		return nil, err
+	}
+	return symmetrickey.NewFromRawBytes(rawKey)
+}
+
+// organizationIDFromJWT returns the organization a machine account belongs
+// to, from the claims of its access token.
+func organizationIDFromJWT(jwt string) (string, error) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed access token")
+	}
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return "", fmt.Errorf("malformed access token claims: %w", err)
+	}
+
+	var claims struct {
+		Organization string `json:"organization"`
+	}
+	err = json.Unmarshal(rawClaims, &claims)
+	if err != nil {
+		return "", fmt.Errorf("malformed access token claims: %w", err)
+	}
+	if len(claims.Organization) == 0 {
+		return "", fmt.Errorf("access token isn't bound to an organization")
+	}
+	return claims.Organization, nil
+}
@@ -0,0 +1,455 @@
+package bws
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bearer"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto/symmetrickey"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
+)
+
+/*
+* This is a client for Bitwarden Secrets Manager, which acts on behalf of a
+* machine account authenticated with an access token. Secrets and projects
+* are encrypted with the key of the organization, which the server hands out
+* encrypted with the access token's encryption key.
+ */
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("access token was rejected")
+)
+
+type Client interface {
+	OrganizationID() (string, error)
+
+	CreateProject(project Project) (*Project, error)
+	DeleteProject(id string) error
+	GetProject(id string) (*Project, error)
+	ListProjects() ([]Project, error)
+	UpdateProject(project Project) (*Project, error)
+
+	CreateSecret(secret Secret) (*Secret, error)
+	DeleteSecret(id string) error
+	GetSecret(id string) (*Secret, error)
+	ListSecrets() ([]Secret, error)
+	UpdateSecret(secret Secret) (*Secret, error)
+
+	CreateMachineAccount(account MachineAccount) (*MachineAccount, error)
+	DeleteMachineAccount(id string) error
+	GetMachineAccount(id string) (*MachineAccount, error)
+	ListMachineAccounts() ([]MachineAccount, error)
+	UpdateMachineAccount(account MachineAccount) (*MachineAccount, error)
+
+	ListProjectAccess(projectID string) ([]ProjectAccess, error)
+	UpdateProjectAccess(projectID string, access []ProjectAccess) ([]ProjectAccess, error)
+
+	CreateAccessToken(machineAccountID string, token MachineAccountToken) (*MachineAccountToken, error)
+	ListAccessTokens(machineAccountID string) ([]MachineAccountToken, error)
+	RevokeAccessToken(machineAccountID, id string) error
+}
+
+func NewClient(ctx context.Context, apiURL, identityURL string, accessToken *AccessToken, opts ...Options) Client {
+	c := &client{
+		accessToken: accessToken,
+		retryPolicy: retry.DefaultPolicy(),
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	credentials := bearer.Credentials{
+		ClientID:     accessToken.ClientID,
+		ClientSecret: accessToken.ClientSecret,
+		Scope:        "api.secrets",
+		Description:  "the access token",
+	}
+	c.api = bearer.NewClient(ctx, "Secrets Manager API", apiURL, identityURL, credentials,
+		bearer.WithRetryPolicy(c.retryPolicy),
+		bearer.WithErrors(ErrNotFound, ErrUnauthorized),
+		bearer.WithLoginHandler(c.login),
+	)
+	return c
+}
+
+type Options func(c *client)
+
+func WithRetryPolicy(policy retry.Policy) Options {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
+}
+
+type client struct {
+	api         *bearer.Client
+	accessToken *AccessToken
+	retryPolicy retry.Policy
+
+	sessionMutex sync.Mutex
+	session      *session
+}
+
+// session is what a login with the access token provides, besides the
+// bearer token.
+type session struct {
+	organizationID  string
+	organizationKey *symmetrickey.Key
+}
+
+func (c *client) OrganizationID() (string, error) {
+	s, err := c.currentSession()
+	if err != nil {
+		return "", err
+	}
+	return s.organizationID, nil
+}
+
+func (c *client) CreateProject(project Project) (*Project, error) {
+	orgID, err := c.OrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.encryptProject(project)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp projectResponse
+	err = c.doJSON("POST", fmt.Sprintf("organizations/%s/projects", orgID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.decryptProject(resp)
+}
+
+func (c *client) DeleteProject(id string) error {
+	return c.bulkDelete("projects/delete", id)
+}
+
+func (c *client) GetProject(id string) (*Project, error) {
+	var resp projectResponse
+	err := c.doJSON("GET", fmt.Sprintf("projects/%s", id), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.decryptProject(resp)
+}
+
+func (c *client) ListProjects() ([]Project, error) {
+	orgID, err := c.OrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp projectsResponse
+	err = c.doJSON("GET", fmt.Sprintf("organizations/%s/projects", orgID), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]Project, 0, len(resp.Data))
+	for _, encrypted := range resp.Data {
+		project, err := c.decryptProject(encrypted)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *project)
+	}
+	return projects, nil
+}
+
+func (c *client) UpdateProject(project Project) (*Project, error) {
+	req, err := c.encryptProject(project)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp projectResponse
+	err = c.doJSON("PUT", fmt.Sprintf("projects/%s", project.ID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.decryptProject(resp)
+}
+
+func (c *client) CreateSecret(secret Secret) (*Secret, error) {
+	orgID, err := c.OrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.encryptSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp secretResponse
+	err = c.doJSON("POST", fmt.Sprintf("organizations/%s/secrets", orgID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.decryptSecret(resp)
+}
+
+func (c *client) DeleteSecret(id string) error {
+	return c.bulkDelete("secrets/delete", id)
+}
+
+func (c *client) GetSecret(id string) (*Secret, error) {
+	var resp secretResponse
+	err := c.doJSON("GET", fmt.Sprintf("secrets/%s", id), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.decryptSecret(resp)
+}
+
+// ListSecrets returns the secrets the machine account has access to. Only
+// their identifiers and keys are returned, values must be retrieved with
+// GetSecret.
+func (c *client) ListSecrets() ([]Secret, error) {
+	orgID, err := c.OrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp secretsResponse
+	err = c.doJSON("GET", fmt.Sprintf("organizations/%s/secrets", orgID), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]Secret, 0, len(resp.Secrets))
+	for _, encrypted := range resp.Secrets {
+		secret, err := c.decryptSecret(encrypted)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, *secret)
+	}
+	return secrets, nil
+}
+
+func (c *client) UpdateSecret(secret Secret) (*Secret, error) {
+	req, err := c.encryptSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp secretResponse
+	err = c.doJSON("PUT", fmt.Sprintf("secrets/%s", secret.ID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.decryptSecret(resp)
+}
+
+// bulkDelete deletes a single object through a bulk deletion endpoint, which
+// reports failures per object.
+func (c *client) bulkDelete(path, id string) error {
+	var resp bulkDeleteResponse
+	err := c.doJSON("POST", path, []string{id}, &resp)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range resp.Data {
+		if result.ID == id && result.Error != nil && len(*result.Error) > 0 {
+			return fmt.Errorf("unable to delete '%s': %s", id, *result.Error)
+		}
+	}
+	return nil
+}
+
+func (c *client) encryptProject(project Project) (*projectRequest, error) {
+	key, err := c.orgKey()
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := crypto.Encrypt([]byte(project.Name), *key)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting project name: %w", err)
+	}
+	return &projectRequest{Name: name}, nil
+}
+
+func (c *client) decryptProject(resp projectResponse) (*Project, error) {
+	name, err := c.decryptString(resp.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting name of project '%s': %w", resp.ID, err)
+	}
+
+	return &Project{
+		ID:             resp.ID,
+		OrganizationID: resp.OrganizationID,
+		Name:           name,
+		CreationDate:   resp.CreationDate,
+		RevisionDate:   resp.RevisionDate,
+	}, nil
+}
+
+func (c *client) encryptSecret(secret Secret) (*secretRequest, error) {
+	key, err := c.orgKey()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &secretRequest{ProjectIDs: []string{}}
+	if len(secret.ProjectID) > 0 {
+		req.ProjectIDs = []string{secret.ProjectID}
+	}
+
+	fields := []struct {
+		name  string
+		value string
+		out   *string
+	}{
+		{"key", secret.Key, &req.Key},
+		{"value", secret.Value, &req.Value},
+		{"note", secret.Note, &req.Note},
+	}
+	for _, field := range fields {
+		*field.out, err = crypto.Encrypt([]byte(field.value), *key)
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting secret %s: %w", field.name, err)
+		}
+	}
+	return req, nil
+}
+
+func (c *client) decryptSecret(resp secretResponse) (*Secret, error) {
+	secret := &Secret{
+		ID:             resp.ID,
+		OrganizationID: resp.OrganizationID,
+		CreationDate:   resp.CreationDate,
+		RevisionDate:   resp.RevisionDate,
+	}
+	if len(resp.Projects) > 0 {
+		secret.ProjectID = resp.Projects[0].ID
+	}
+
+	fields := []struct {
+		name  string
+		value string
+		out   *string
+	}{
+		{"key", resp.Key, &secret.Key},
+		{"value", resp.Value, &secret.Value},
+		{"note", resp.Note, &secret.Note},
+	}
+	for _, field := range fields {
+		var err error
+		*field.out, err = c.decryptString(field.value)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting %s of secret '%s': %w", field.name, resp.ID, err)
+		}
+	}
+	return secret, nil
+}
+
+// decryptString decrypts a value encrypted with the organization key. Empty
+// values aren't encrypted.
+func (c *client) decryptString(value string) (string, error) {
+	if len(value) == 0 {
+		return "", nil
+	}
+
+	key, err := c.orgKey()
+	if err != nil {
+		return "", err
+	}
+
+	decrypted, err := crypto.Decrypt(value, *key)
+	if err != nil {
+		return "", err
+	}
+	return string(decrypted), nil
+}
+
+func (c *client) orgKey() (*symmetrickey.Key, error) {
+	s, err := c.currentSession()
+	if err != nil {
+		return nil, err
+	}
+	return s.organizationKey, nil
+}
+
+// doJSON sends a request to the API and decodes its response into out.
+func (c *client) doJSON(method, path string, in, out interface{}) error {
+	return c.api.DoJSON(method, path, nil, in, out)
+}
+
+// currentSession returns the session of a valid login, logging in with the
+// access token when needed.
+func (c *client) currentSession() (*session, error) {
+	_, err := c.api.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	return c.session, nil
+}
+
+// login reads the organization of the machine account and its key from a
+// token response.
+func (c *client) login(body []byte) error {
+	var tokenResp TokenResponse
+	err := json.Unmarshal(body, &tokenResp)
+	if err != nil {
+		return fmt.Errorf("error unmarshalling token response: %w", err)
+	}
+
+	orgID, err := organizationIDFromJWT(tokenResp.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	orgKey, err := c.decryptOrganizationKey(tokenResp.EncryptedPayload)
+	if err != nil {
+		return err
+	}
+
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+
+	c.session = &session{
+		organizationID:  orgID,
+		organizationKey: orgKey,
+	}
+	return nil
+}
+
+func (c *client) decryptOrganizationKey(encryptedPayload string) (*symmetrickey.Key, error) {
+	payloadKey, err := c.accessToken.payloadKey()
+	if err != nil {
+		return nil, fmt.Errorf("error deriving key of the access token: %w", err)
+	}
+
+	rawPayload, err := crypto.Decrypt(encryptedPayload, *payloadKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting token payload: %w", err)
+	}
+
+	var payload TokenPayload
+	err = json.Unmarshal(rawPayload, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling token payload: %w", err)
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(payload.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding organization key: %w", err)
+	}
+	return symmetrickey.NewFromRawBytes(rawKey)
+}
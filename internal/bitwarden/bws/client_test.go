@@ -0,0 +1,124 @@
+package bws
+
+import (
+	"context"
+	"testing"
+
+	test_bws "github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, server *test_bws.Server) Client {
+	accessToken, err := ParseAccessToken(server.AccessToken)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return NewClient(context.Background(), server.URL+"/api", server.URL+"/identity", accessToken)
+}
+
+func TestParseAccessToken(t *testing.T) {
+	token, err := ParseAccessToken("0.ec2c1d46-6a4b-4751-a310-af9601317f2d.C2IgxjjLF7qSshsbwe8JGcbM075YXw:X8vbvA0bduihIDe/qrzIQQ==")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ec2c1d46-6a4b-4751-a310-af9601317f2d", token.ClientID)
+		assert.Equal(t, "C2IgxjjLF7qSshsbwe8JGcbM075YXw", token.ClientSecret)
+		assert.Len(t, token.EncryptionKey, 16)
+	}
+
+	_, err = ParseAccessToken("0.ec2c1d46-6a4b-4751-a310-af9601317f2d.C2IgxjjLF7qSshsbwe8JGcbM075YXw")
+	assert.EqualError(t, err, "invalid access token: missing encryption key")
+
+	_, err = ParseAccessToken("1.ec2c1d46-6a4b-4751-a310-af9601317f2d.C2IgxjjLF7qSshsbwe8JGcbM075YXw:X8vbvA0bduihIDe/qrzIQQ==")
+	assert.EqualError(t, err, "invalid access token: unsupported version '1'")
+}
+
+func TestProjectLifecycle(t *testing.T) {
+	server := test_bws.NewServer(t)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	project, err := client.CreateProject(Project{Name: "Production"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, test_bws.OrganizationID, project.OrganizationID)
+	assert.Equal(t, "Production", project.Name)
+
+	project.Name = "Staging"
+	_, err = client.UpdateProject(*project)
+	assert.NoError(t, err)
+
+	projects, err := client.ListProjects()
+	if assert.NoError(t, err) && assert.Len(t, projects, 1) {
+		assert.Equal(t, "Staging", projects[0].Name)
+	}
+
+	assert.NoError(t, client.DeleteProject(project.ID))
+	_, err = client.GetProject(project.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, client.DeleteProject(project.ID), "unable to delete '"+project.ID+"': Not found")
+}
+
+func TestSecretLifecycle(t *testing.T) {
+	server := test_bws.NewServer(t)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	project, err := client.CreateProject(Project{Name: "Production"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	secret, err := client.CreateSecret(Secret{Key: "DB_PASSWORD", Value: "s3cr3t", ProjectID: project.ID})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, project.ID, secret.ProjectID)
+	assert.Equal(t, "", secret.Note)
+
+	secret.Note = "Rotated monthly"
+	_, err = client.UpdateSecret(*secret)
+	assert.NoError(t, err)
+
+	fetched, err := client.GetSecret(secret.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "DB_PASSWORD", fetched.Key)
+		assert.Equal(t, "s3cr3t", fetched.Value)
+		assert.Equal(t, "Rotated monthly", fetched.Note)
+	}
+
+	secrets, err := client.ListSecrets()
+	if assert.NoError(t, err) && assert.Len(t, secrets, 1) {
+		assert.Equal(t, "DB_PASSWORD", secrets[0].Key)
+	}
+
+	assert.NoError(t, client.DeleteSecret(secret.ID))
+	_, err = client.GetSecret(secret.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSessionIsRenewedWhenRejected(t *testing.T) {
+	server := test_bws.NewServer(t)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	_, err := client.ListProjects()
+	assert.NoError(t, err)
+
+	server.RevokeTokens()
+
+	_, err = client.ListProjects()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, server.TokensIssued)
+}
+
+func TestInvalidAccessToken(t *testing.T) {
+	server := test_bws.NewServer(t)
+	defer server.Close()
+
+	accessToken, err := ParseAccessToken("0.ec2c1d46-6a4b-4751-a310-af9601317f2d.wrong-secret:X8vbvA0bduihIDe/qrzIQQ==")
+	assert.NoError(t, err)
+
+	_, err = NewClient(context.Background(), server.URL+"/api", server.URL+"/identity", accessToken).ListProjects()
+	assert.ErrorContains(t, err, "error logging in with the access token")
+}
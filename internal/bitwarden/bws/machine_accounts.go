@@ -0,0 +1,246 @@
+package bws
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto"
+)
+
+func (c *client) CreateMachineAccount(account MachineAccount) (*MachineAccount, error) {
+	orgID, err := c.OrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.encryptMachineAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp machineAccountResponse
+	err = c.doJSON("POST", fmt.Sprintf("organizations/%s/service-accounts", orgID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.decryptMachineAccount(resp)
+}
+
+func (c *client) DeleteMachineAccount(id string) error {
+	return c.bulkDelete("service-accounts/delete", id)
+}
+
+func (c *client) GetMachineAccount(id string) (*MachineAccount, error) {
+	var resp machineAccountResponse
+	err := c.doJSON("GET", fmt.Sprintf("service-accounts/%s", id), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.decryptMachineAccount(resp)
+}
+
+func (c *client) ListMachineAccounts() ([]MachineAccount, error) {
+	orgID, err := c.OrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp machineAccountsResponse
+	err = c.doJSON("GET", fmt.Sprintf("organizations/%s/service-accounts", orgID), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]MachineAccount, 0, len(resp.Data))
+	for _, encrypted := range resp.Data {
+		account, err := c.decryptMachineAccount(encrypted)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *account)
+	}
+	return accounts, nil
+}
+
+func (c *client) UpdateMachineAccount(account MachineAccount) (*MachineAccount, error) {
+	req, err := c.encryptMachineAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp machineAccountResponse
+	err = c.doJSON("PUT", fmt.Sprintf("service-accounts/%s", account.ID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.decryptMachineAccount(resp)
+}
+
+func (c *client) ListProjectAccess(projectID string) ([]ProjectAccess, error) {
+	var resp projectAccessPoliciesResponse
+	err := c.doJSON("GET", fmt.Sprintf("projects/%s/access-policies/service-accounts", projectID), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return projectAccessFromResponse(resp), nil
+}
+
+// UpdateProjectAccess replaces the permissions of every machine account on
+// a project: machine accounts missing from access lose their access.
+func (c *client) UpdateProjectAccess(projectID string, access []ProjectAccess) ([]ProjectAccess, error) {
+	req := projectAccessPoliciesRequest{ServiceAccountAccessPolicyRequests: make([]accessPolicyRequest, 0, len(access))}
+	for _, a := range access {
+		req.ServiceAccountAccessPolicyRequests = append(req.ServiceAccountAccessPolicyRequests, accessPolicyRequest{
+			GranteeID: a.MachineAccountID,
+			Read:      a.Read,
+			Write:     a.Write,
+		})
+	}
+
+	var resp projectAccessPoliciesResponse
+	err := c.doJSON("PUT", fmt.Sprintf("projects/%s/access-policies/service-accounts", projectID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return projectAccessFromResponse(resp), nil
+}
+
+// CreateAccessToken issues a new access token for a machine account. Like
+// Bitwarden clients do, a new encryption key is generated for the token, and
+// the organization key is handed to the server encrypted with it.
+func (c *client) CreateAccessToken(machineAccountID string, token MachineAccountToken) (*MachineAccountToken, error) {
+	orgKey, err := c.orgKey()
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken := &AccessToken{EncryptionKey: make([]byte, 16)}
+	_, err = rand.Read(accessToken.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("error generating encryption key of the access token: %w", err)
+	}
+
+	payloadKey, err := accessToken.payloadKey()
+	if err != nil {
+		return nil, fmt.Errorf("error deriving key of the access token: %w", err)
+	}
+
+	payload, err := json.Marshal(TokenPayload{EncryptionKey: base64.StdEncoding.EncodeToString(orgKey.Key)})
+	if err != nil {
+		return nil, err
+	}
+
+	req := accessTokenRequest{ExpireAt: token.ExpirationDate}
+	req.EncryptedPayload, err = crypto.Encrypt(payload, *payloadKey)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting access token payload: %w", err)
+	}
+
+	req.Key, err = crypto.Encrypt([]byte(base64.StdEncoding.EncodeToString(accessToken.EncryptionKey)), *orgKey)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting access token key: %w", err)
+	}
+
+	req.Name, err = crypto.Encrypt([]byte(token.Name), *orgKey)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting access token name: %w", err)
+	}
+
+	var resp accessTokenResponse
+	err = c.doJSON("POST", fmt.Sprintf("service-accounts/%s/access-tokens", machineAccountID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := c.decryptAccessToken(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken.ClientID = resp.ID
+	accessToken.ClientSecret = resp.ClientSecret
+	created.Value = accessToken.String()
+	return created, nil
+}
+
+// ListAccessTokens returns the access tokens of a machine account, without
+// their values.
+func (c *client) ListAccessTokens(machineAccountID string) ([]MachineAccountToken, error) {
+	var resp accessTokensResponse
+	err := c.doJSON("GET", fmt.Sprintf("service-accounts/%s/access-tokens", machineAccountID), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]MachineAccountToken, 0, len(resp.Data))
+	for _, encrypted := range resp.Data {
+		token, err := c.decryptAccessToken(encrypted)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+	return tokens, nil
+}
+
+func (c *client) RevokeAccessToken(machineAccountID, id string) error {
+	req := revokeAccessTokensRequest{IDs: []string{id}}
+	return c.doJSON("POST", fmt.Sprintf("service-accounts/%s/access-tokens/revoke", machineAccountID), req, nil)
+}
+
+func (c *client) encryptMachineAccount(account MachineAccount) (*machineAccountRequest, error) {
+	key, err := c.orgKey()
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := crypto.Encrypt([]byte(account.Name), *key)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting machine account name: %w", err)
+	}
+	return &machineAccountRequest{Name: name}, nil
+}
+
+func (c *client) decryptMachineAccount(resp machineAccountResponse) (*MachineAccount, error) {
+	name, err := c.decryptString(resp.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting name of machine account '%s': %w", resp.ID, err)
+	}
+
+	return &MachineAccount{
+		ID:             resp.ID,
+		OrganizationID: resp.OrganizationID,
+		Name:           name,
+		CreationDate:   resp.CreationDate,
+		RevisionDate:   resp.RevisionDate,
+	}, nil
+}
+
+func (c *client) decryptAccessToken(resp accessTokenResponse) (*MachineAccountToken, error) {
+	name, err := c.decryptString(resp.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting name of access token '%s': %w", resp.ID, err)
+	}
+
+	return &MachineAccountToken{
+		ID:             resp.ID,
+		Name:           name,
+		ExpirationDate: resp.ExpireAt,
+		CreationDate:   resp.CreationDate,
+		RevisionDate:   resp.RevisionDate,
+	}, nil
+}
+
+func projectAccessFromResponse(resp projectAccessPoliciesResponse) []ProjectAccess {
+	access := make([]ProjectAccess, 0, len(resp.ServiceAccountAccessPolicies))
+	for _, policy := range resp.ServiceAccountAccessPolicies {
+		access = append(access, ProjectAccess{
+			MachineAccountID: policy.ServiceAccountID,
+			Read:             policy.Read,
+			Write:            policy.Write,
+		})
+	}
+	return access
+}
@@ -0,0 +1,104 @@
+package bws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	test_bws "github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMachineAccountLifecycle(t *testing.T) {
+	server := test_bws.NewServer(t)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	account, err := client.CreateMachineAccount(MachineAccount{Name: "CI"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, test_bws.OrganizationID, account.OrganizationID)
+
+	account.Name = "GitHub Actions"
+	_, err = client.UpdateMachineAccount(*account)
+	assert.NoError(t, err)
+
+	accounts, err := client.ListMachineAccounts()
+	if assert.NoError(t, err) && assert.Len(t, accounts, 1) {
+		assert.Equal(t, "GitHub Actions", accounts[0].Name)
+	}
+
+	assert.NoError(t, client.DeleteMachineAccount(account.ID))
+	_, err = client.GetMachineAccount(account.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestUpdateProjectAccess(t *testing.T) {
+	server := test_bws.NewServer(t)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	project, err := client.CreateProject(Project{Name: "Production"})
+	assert.NoError(t, err)
+	account, err := client.CreateMachineAccount(MachineAccount{Name: "CI"})
+	assert.NoError(t, err)
+
+	access, err := client.ListProjectAccess(project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, access)
+
+	_, err = client.UpdateProjectAccess(project.ID, []ProjectAccess{{MachineAccountID: account.ID, Read: true}})
+	assert.NoError(t, err)
+
+	access, err = client.ListProjectAccess(project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []ProjectAccess{{MachineAccountID: account.ID, Read: true}}, access)
+
+	_, err = client.ListProjectAccess("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAccessTokenCanBeUsedUntilRevoked(t *testing.T) {
+	server := test_bws.NewServer(t)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	account, err := client.CreateMachineAccount(MachineAccount{Name: "CI"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = client.CreateSecret(Secret{Key: "DB_PASSWORD", Value: "s3cr3t"})
+	assert.NoError(t, err)
+
+	expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	token, err := client.CreateAccessToken(account.ID, MachineAccountToken{Name: "GitHub Actions", ExpirationDate: &expiry})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "GitHub Actions", token.Name)
+	assert.True(t, expiry.Equal(*token.ExpirationDate))
+
+	tokens, err := client.ListAccessTokens(account.ID)
+	if assert.NoError(t, err) && assert.Len(t, tokens, 1) {
+		assert.Equal(t, token.ID, tokens[0].ID)
+		assert.Empty(t, tokens[0].Value)
+	}
+
+	// The new token decrypts the organization key on its own.
+	accessToken, err := ParseAccessToken(token.Value)
+	if !assert.NoError(t, err) {
+		return
+	}
+	newClient := NewClient(context.Background(), server.URL+"/api", server.URL+"/identity", accessToken)
+	secrets, err := newClient.ListSecrets()
+	if assert.NoError(t, err) && assert.Len(t, secrets, 1) {
+		assert.Equal(t, "DB_PASSWORD", secrets[0].Key)
+	}
+
+	assert.NoError(t, client.RevokeAccessToken(account.ID, token.ID))
+
+	revokedClient := NewClient(context.Background(), server.URL+"/api", server.URL+"/identity", accessToken)
+	_, err = revokedClient.ListSecrets()
+	assert.ErrorContains(t, err, "error logging in with the access token")
+}
@@ -0,0 +1,173 @@
+package bws
+
+import "time"
+
+type TokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	TokenType        string `json:"token_type"`
+	EncryptedPayload string `json:"encrypted_payload"`
+}
+
+// TokenPayload is the decrypted 'encrypted_payload' of a token response.
+type TokenPayload struct {
+	EncryptionKey string `json:"encryptionKey"`
+}
+
+// Project is a decrypted Secrets Manager project.
+type Project struct {
+	ID             string
+	OrganizationID string
+	Name           string
+	CreationDate   *time.Time
+	RevisionDate   *time.Time
+}
+
+// Secret is a decrypted Secrets Manager secret.
+type Secret struct {
+	ID             string
+	OrganizationID string
+	ProjectID      string
+	Key            string
+	Value          string
+	Note           string
+	CreationDate   *time.Time
+	RevisionDate   *time.Time
+}
+
+// MachineAccount is a decrypted Secrets Manager machine account.
+type MachineAccount struct {
+	ID             string
+	OrganizationID string
+	Name           string
+	CreationDate   *time.Time
+	RevisionDate   *time.Time
+}
+
+// ProjectAccess is the permission a machine account has on a project.
+type ProjectAccess struct {
+	MachineAccountID string
+	Read             bool
+	Write            bool
+}
+
+// MachineAccountToken is an access token of a machine account. Its value is
+// only known right after its creation.
+type MachineAccountToken struct {
+	ID             string
+	Name           string
+	Value          string
+	ExpirationDate *time.Time
+	CreationDate   *time.Time
+	RevisionDate   *time.Time
+}
+
+type projectResponse struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organizationId"`
+	Name           string     `json:"name"`
+	CreationDate   *time.Time `json:"creationDate"`
+	RevisionDate   *time.Time `json:"revisionDate"`
+}
+
+type projectRequest struct {
+	Name string `json:"name"`
+}
+
+type projectsResponse struct {
+	Data []projectResponse `json:"data"`
+}
+
+type secretProjectResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type secretResponse struct {
+	ID             string                  `json:"id"`
+	OrganizationID string                  `json:"organizationId"`
+	Key            string                  `json:"key"`
+	Value          string                  `json:"value"`
+	Note           string                  `json:"note"`
+	CreationDate   *time.Time              `json:"creationDate"`
+	RevisionDate   *time.Time              `json:"revisionDate"`
+	Projects       []secretProjectResponse `json:"projects"`
+}
+
+type secretRequest struct {
+	Key        string   `json:"key"`
+	Value      string   `json:"value"`
+	Note       string   `json:"note"`
+	ProjectIDs []string `json:"projectIds"`
+}
+
+type secretsResponse struct {
+	Secrets []secretResponse `json:"secrets"`
+}
+
+type bulkDeleteResponse struct {
+	Data []struct {
+		ID    string  `json:"id"`
+		Error *string `json:"error"`
+	} `json:"data"`
+}
+
+type machineAccountResponse struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organizationId"`
+	Name           string     `json:"name"`
+	CreationDate   *time.Time `json:"creationDate"`
+	RevisionDate   *time.Time `json:"revisionDate"`
+}
+
+type machineAccountRequest struct {
+	Name string `json:"name"`
+}
+
+type machineAccountsResponse struct {
+	Data []machineAccountResponse `json:"data"`
+}
+
+type accessPolicyResponse struct {
+	ServiceAccountID string `json:"serviceAccountId"`
+	Read             bool   `json:"read"`
+	Write            bool   `json:"write"`
+}
+
+type projectAccessPoliciesResponse struct {
+	ServiceAccountAccessPolicies []accessPolicyResponse `json:"serviceAccountAccessPolicies"`
+}
+
+type accessPolicyRequest struct {
+	GranteeID string `json:"granteeId"`
+	Read      bool   `json:"read"`
+	Write     bool   `json:"write"`
+}
+
+type projectAccessPoliciesRequest struct {
+	ServiceAccountAccessPolicyRequests []accessPolicyRequest `json:"serviceAccountAccessPolicyRequests"`
+}
+
+type accessTokenResponse struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	ClientSecret string     `json:"clientSecret,omitempty"`
+	ExpireAt     *time.Time `json:"expireAt"`
+	CreationDate *time.Time `json:"creationDate"`
+	RevisionDate *time.Time `json:"revisionDate"`
+}
+
+type accessTokenRequest struct {
+	Name             string     `json:"name"`
+	EncryptedPayload string     `json:"encryptedPayload"`
+	Key              string     `json:"key"`
+	ExpireAt         *time.Time `json:"expireAt"`
+}
+
+type accessTokensResponse struct {
+	Data []accessTokenResponse `json:"data"`
+}
+
+type revokeAccessTokensRequest struct {
+	IDs []string `json:"ids"`
+}
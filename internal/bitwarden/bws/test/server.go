@@ -0,0 +1,443 @@
+package test
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto/symmetrickey"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	OrganizationID = "org-1"
+
+	clientID     = "ec2c1d46-6a4b-4751-a310-af9601317f2d"
+	clientSecret = "C2IgxjjLF7qSshsbwe8JGcbM075YXw"
+)
+
+// Server is a stand-in for the Secrets Manager API, which stores objects as
+// they are sent by clients, i.e. encrypted.
+type Server struct {
+	*httptest.Server
+
+	AccessToken  string
+	TokensIssued int
+
+	mutex           sync.Mutex
+	nextID          int
+	revokedTokens   map[string]bool
+	projects        map[string]map[string]interface{}
+	secrets         map[string]map[string]interface{}
+	machineAccounts map[string]map[string]interface{}
+	accessPolicies  map[string][]map[string]interface{}
+	accessTokens    map[string]*accessToken
+}
+
+// accessToken is an access token issued by the server for a machine account.
+type accessToken struct {
+	machineAccountID string
+	clientSecret     string
+	encryptedPayload string
+	attributes       map[string]interface{}
+}
+
+func NewServer(t *testing.T) *Server {
+	encryptionKey := make([]byte, 16)
+	_, err := rand.Read(encryptionKey)
+	assert.NoError(t, err)
+
+	s := &Server{
+		AccessToken:     fmt.Sprintf("0.%s.%s:%s", clientID, clientSecret, base64.StdEncoding.EncodeToString(encryptionKey)),
+		revokedTokens:   map[string]bool{},
+		projects:        map[string]map[string]interface{}{},
+		secrets:         map[string]map[string]interface{}{},
+		machineAccounts: map[string]map[string]interface{}{},
+		accessPolicies:  map[string][]map[string]interface{}{},
+		accessTokens:    map[string]*accessToken{},
+	}
+
+	encryptedPayload := newEncryptedPayload(t, encryptionKey)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /identity/connect/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "api.secrets", r.PostForm.Get("scope"))
+
+		s.mutex.Lock()
+		payload, ok := s.tokenPayload(r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"), encryptedPayload)
+		if !ok {
+			s.mutex.Unlock()
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		s.TokensIssued = s.TokensIssued + 1
+		token := newJWT(s.TokensIssued)
+		s.mutex.Unlock()
+
+		writeJSON(w, map[string]interface{}{
+			"access_token":      token,
+			"expires_in":        3600,
+			"token_type":        "Bearer",
+			"encrypted_payload": payload,
+		})
+	})
+
+	s.handleObjects(mux, "projects", s.projects, func(in map[string]interface{}, out map[string]interface{}) {
+		out["name"] = in["name"]
+	})
+	s.handleObjects(mux, "secrets", s.secrets, func(in map[string]interface{}, out map[string]interface{}) {
+		out["key"] = in["key"]
+		out["value"] = in["value"]
+		out["note"] = in["note"]
+		out["projects"] = s.secretProjects(in["projectIds"])
+	})
+	s.handleObjects(mux, "service-accounts", s.machineAccounts, func(in map[string]interface{}, out map[string]interface{}) {
+		out["name"] = in["name"]
+	})
+	s.handleAccessPolicies(mux)
+	s.handleAccessTokens(mux)
+
+	s.Server = httptest.NewServer(s.authenticated(mux))
+	return s
+}
+
+// RevokeTokens makes the server reject every access token issued so far.
+func (s *Server) RevokeTokens() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := 1; i <= s.TokensIssued; i++ {
+		s.revokedTokens[fmt.Sprintf("Bearer %s", newJWT(i))] = true
+	}
+}
+
+func (s *Server) authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/identity/connect/token" {
+			s.mutex.Lock()
+			revoked := s.revokedTokens[r.Header.Get("Authorization")]
+			s.mutex.Unlock()
+
+			if revoked || len(r.Header.Get("Authorization")) == 0 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleObjects serves the endpoints of a kind of object, copying attributes
+// of requests onto stored objects with update.
+func (s *Server) handleObjects(mux *http.ServeMux, kind string, objects map[string]map[string]interface{}, update func(in, out map[string]interface{})) {
+	mux.HandleFunc(fmt.Sprintf("GET /api/organizations/{orgID}/%s", kind), func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		list := []map[string]interface{}{}
+		for _, obj := range objects {
+			if obj["organizationId"] == r.PathValue("orgID") {
+				list = append(list, obj)
+			}
+		}
+
+		if kind == "secrets" {
+			writeJSON(w, map[string]interface{}{"secrets": list})
+		} else {
+			writeJSON(w, map[string]interface{}{"data": list})
+		}
+	})
+
+	mux.HandleFunc(fmt.Sprintf("POST /api/organizations/{orgID}/%s", kind), func(w http.ResponseWriter, r *http.Request) {
+		var in map[string]interface{}
+		if !readJSON(w, r, &in) {
+			return
+		}
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		s.nextID = s.nextID + 1
+		now := time.Now().UTC()
+		obj := map[string]interface{}{
+			"id":             fmt.Sprintf("%s-%d", kind[:len(kind)-1], s.nextID),
+			"organizationId": r.PathValue("orgID"),
+			"creationDate":   now,
+			"revisionDate":   now,
+		}
+		update(in, obj)
+		objects[obj["id"].(string)] = obj
+		writeJSON(w, obj)
+	})
+
+	mux.HandleFunc(fmt.Sprintf("GET /api/%s/{id}", kind), func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		obj, ok := objects[r.PathValue("id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, obj)
+	})
+
+	mux.HandleFunc(fmt.Sprintf("PUT /api/%s/{id}", kind), func(w http.ResponseWriter, r *http.Request) {
+		var in map[string]interface{}
+		if !readJSON(w, r, &in) {
+			return
+		}
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		obj, ok := objects[r.PathValue("id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		update(in, obj)
+		obj["revisionDate"] = time.Now().UTC()
+		writeJSON(w, obj)
+	})
+
+	mux.HandleFunc(fmt.Sprintf("POST /api/%s/delete", kind), func(w http.ResponseWriter, r *http.Request) {
+		var ids []string
+		if !readJSON(w, r, &ids) {
+			return
+		}
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		results := []map[string]interface{}{}
+		for _, id := range ids {
+			result := map[string]interface{}{"id": id, "error": nil}
+			if _, ok := objects[id]; ok {
+				delete(objects, id)
+			} else {
+				result["error"] = "Not found"
+			}
+			results = append(results, result)
+		}
+		writeJSON(w, map[string]interface{}{"data": results})
+	})
+}
+
+func (s *Server) handleAccessPolicies(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/projects/{id}/access-policies/service-accounts", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if _, ok := s.projects[r.PathValue("id")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, s.projectAccessPolicies(r.PathValue("id")))
+	})
+
+	mux.HandleFunc("PUT /api/projects/{id}/access-policies/service-accounts", func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Requests []struct {
+				GranteeID string `json:"granteeId"`
+				Read      bool   `json:"read"`
+				Write     bool   `json:"write"`
+			} `json:"serviceAccountAccessPolicyRequests"`
+		}
+		if !readJSON(w, r, &in) {
+			return
+		}
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if _, ok := s.projects[r.PathValue("id")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		policies := []map[string]interface{}{}
+		for _, req := range in.Requests {
+			if _, ok := s.machineAccounts[req.GranteeID]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			policies = append(policies, map[string]interface{}{
+				"serviceAccountId": req.GranteeID,
+				"read":             req.Read,
+				"write":            req.Write,
+			})
+		}
+		s.accessPolicies[r.PathValue("id")] = policies
+		writeJSON(w, s.projectAccessPolicies(r.PathValue("id")))
+	})
+}
+
+func (s *Server) projectAccessPolicies(projectID string) map[string]interface{} {
+	policies := s.accessPolicies[projectID]
+	if policies == nil {
+		policies = []map[string]interface{}{}
+	}
+	return map[string]interface{}{"serviceAccountAccessPolicies": policies}
+}
+
+func (s *Server) handleAccessTokens(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/service-accounts/{id}/access-tokens", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		list := []map[string]interface{}{}
+		for _, token := range s.accessTokens {
+			if token.machineAccountID == r.PathValue("id") {
+				list = append(list, token.attributes)
+			}
+		}
+		writeJSON(w, map[string]interface{}{"data": list})
+	})
+
+	mux.HandleFunc("POST /api/service-accounts/{id}/access-tokens", func(w http.ResponseWriter, r *http.Request) {
+		var in map[string]interface{}
+		if !readJSON(w, r, &in) {
+			return
+		}
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if _, ok := s.machineAccounts[r.PathValue("id")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.nextID = s.nextID + 1
+		now := time.Now().UTC()
+		token := &accessToken{
+			machineAccountID: r.PathValue("id"),
+			clientSecret:     fmt.Sprintf("secret-%d", s.nextID),
+			encryptedPayload: in["encryptedPayload"].(string),
+			attributes: map[string]interface{}{
+				"id":           fmt.Sprintf("access-token-%d", s.nextID),
+				"name":         in["name"],
+				"expireAt":     in["expireAt"],
+				"creationDate": now,
+				"revisionDate": now,
+			},
+		}
+		s.accessTokens[token.attributes["id"].(string)] = token
+
+		out := map[string]interface{}{"clientSecret": token.clientSecret}
+		for k, v := range token.attributes {
+			out[k] = v
+		}
+		writeJSON(w, out)
+	})
+
+	mux.HandleFunc("POST /api/service-accounts/{id}/access-tokens/revoke", func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			IDs []string `json:"ids"`
+		}
+		if !readJSON(w, r, &in) {
+			return
+		}
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		for _, id := range in.IDs {
+			if token, ok := s.accessTokens[id]; ok && token.machineAccountID == r.PathValue("id") {
+				delete(s.accessTokens, id)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// tokenPayload returns the encrypted payload of the access token matching
+// the given credentials, which is the server's own token by default.
+func (s *Server) tokenPayload(id, secret, defaultPayload string) (string, bool) {
+	if id == clientID {
+		return defaultPayload, secret == clientSecret
+	}
+
+	token, ok := s.accessTokens[id]
+	if !ok || token.clientSecret != secret {
+		return "", false
+	}
+	if expireAt, ok := token.attributes["expireAt"].(string); ok {
+		expiry, err := time.Parse(time.RFC3339, expireAt)
+		if err != nil || time.Now().After(expiry) {
+			return "", false
+		}
+	}
+	return token.encryptedPayload, true
+}
+
+func (s *Server) secretProjects(projectIDs interface{}) []map[string]interface{} {
+	projects := []map[string]interface{}{}
+	ids, _ := projectIDs.([]interface{})
+	for _, id := range ids {
+		if project, ok := s.projects[id.(string)]; ok {
+			projects = append(projects, map[string]interface{}{"id": project["id"], "name": project["name"]})
+		}
+	}
+	return projects
+}
+
+// newEncryptedPayload returns a new organization key, encrypted the way
+// Bitwarden does for token responses of machine accounts.
+func newEncryptedPayload(t *testing.T, encryptionKey []byte) string {
+	mac := hmac.New(sha256.New, []byte("bitwarden-accesstoken"))
+	mac.Write(encryptionKey)
+
+	rawPayloadKey := make([]byte, 64)
+	_, err := hkdf.Expand(sha256.New, mac.Sum(nil), []byte("sm-access-token")).Read(rawPayloadKey)
+	assert.NoError(t, err)
+
+	payloadKey, err := symmetrickey.NewFromRawBytes(rawPayloadKey)
+	assert.NoError(t, err)
+
+	orgKey := make([]byte, 64)
+	_, err = rand.Read(orgKey)
+	assert.NoError(t, err)
+
+	payload, err := json.Marshal(map[string]string{"encryptionKey": base64.StdEncoding.EncodeToString(orgKey)})
+	assert.NoError(t, err)
+
+	encryptedPayload, err := crypto.Encrypt(payload, *payloadKey)
+	assert.NoError(t, err)
+	return encryptedPayload
+}
+
+func newJWT(serial int) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"organization":"%s","jti":"%d"}`, OrganizationID, serial)))
+	return fmt.Sprintf("%s.%s.signature", header, claims)
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, in interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(in)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"message": "%v"}`, err)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, out interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
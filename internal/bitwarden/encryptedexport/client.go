@@ -0,0 +1,204 @@
+package encryptedexport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto/keybuilder"
+)
+
+var (
+	ErrReadOnly             = errors.New("encrypted exports are read-only")
+	ErrNotPasswordProtected = errors.New("only password-protected exports are supported")
+	ErrInvalidPassword      = errors.New("invalid export password")
+)
+
+/*
+* This is a read-only client serving objects from a password-protected
+* Bitwarden JSON export. It allows reading secrets from backups when
+* the Bitwarden server can't be reached.
+ */
+
+// NewClientFromFile reads and decrypts the export located at 'path'.
+func NewClientFromFile(path, password string) (bw.Client, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading export: %w", err)
+	}
+
+	export, err := Decrypt(data, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(export), nil
+}
+
+// NewClient returns a client serving the objects of a decrypted export.
+func NewClient(export *Export) bw.Client {
+	c := &client{}
+
+	for _, obj := range export.Items {
+		obj.Object = bw.ObjectTypeItem
+		c.items = append(c.items, obj)
+	}
+	for _, obj := range export.Folders {
+		obj.Object = bw.ObjectTypeFolder
+		c.folders = append(c.folders, obj)
+	}
+	for _, obj := range export.Collections {
+		obj.Object = bw.ObjectTypeOrgCollection
+		c.collections = append(c.collections, obj)
+	}
+	return c
+}
+
+// Decrypt derives the export key from the password and the KDF settings
+// found in the export, and decrypts its content.
+func Decrypt(data []byte, password string) (*Export, error) {
+	var encExport EncryptedExport
+	err := json.Unmarshal(data, &encExport)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling export: %w", err)
+	}
+
+	if !encExport.Encrypted || !encExport.PasswordProtected {
+		return nil, ErrNotPasswordProtected
+	}
+
+	memory, parallelism := 0, 0
+	if encExport.KdfMemory != nil {
+		memory = *encExport.KdfMemory
+	}
+	if encExport.KdfParallelism != nil {
+		parallelism = *encExport.KdfParallelism
+	}
+
+	key, err := keybuilder.BuildPasswordProtectedExportKey(password, encExport.Salt, encExport.KdfType, encExport.KdfIterations, memory, parallelism)
+	if err != nil {
+		return nil, fmt.Errorf("error building export key: %w", err)
+	}
+
+	_, err = crypto.Decrypt(encExport.EncKeyValidation, *key)
+	if err != nil {
+		return nil, ErrInvalidPassword
+	}
+
+	decrypted, err := crypto.Decrypt(encExport.Data, *key)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting export: %w", err)
+	}
+
+	var export Export
+	err = json.Unmarshal(decrypted, &export)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling decrypted export: %w", err)
+	}
+	return &export, nil
+}
+
+type client struct {
+	collections []bw.Object
+	folders     []bw.Object
+	items       []bw.Object
+}
+
+func (c *client) CreateAttachment(itemId, filePath string) (*bw.Object, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *client) CreateObject(bw.Object) (*bw.Object, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *client) EditItemCollections(itemID string, collectionIDs []string) (*bw.Object, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *client) EditObject(bw.Object) (*bw.Object, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *client) GetAttachment(itemId, attachmentId string) ([]byte, error) {
+	return nil, fmt.Errorf("encrypted exports don't contain attachments")
+}
+
+func (c *client) GetObject(obj bw.Object) (*bw.Object, error) {
+	for _, candidate := range c.objects(obj.Object) {
+		if candidate.ID == obj.ID {
+			return &candidate, nil
+		}
+	}
+	return nil, bw.ErrObjectNotFound
+}
+
+func (c *client) GetSessionKey() string {
+	return ""
+}
+
+func (c *client) ListObjects(objType string, options ...bw.ListObjectsOption) ([]bw.Object, error) {
+	objs := c.objects(bw.ObjectType(strings.TrimSuffix(objType, "s")))
+	return filterObjects(objs, options...), nil
+}
+
+func (c *client) LoginWithAPIKey(password, clientId, clientSecret string) error {
+	return fmt.Errorf("encrypted export client doesn't support login")
+}
+
+func (c *client) LoginWithPassword(username, password string, options ...bw.LoginOption) error {
+	return fmt.Errorf("encrypted export client doesn't support login")
+}
+
+func (c *client) Logout() error {
+	return fmt.Errorf("encrypted export client doesn't support logout")
+}
+
+func (c *client) DeleteAttachment(itemId, attachmentId string) error {
+	return ErrReadOnly
+}
+
+func (c *client) DeleteObject(bw.Object) error {
+	return ErrReadOnly
+}
+
+func (c *client) SetServer(bw.Environment) error {
+	return fmt.Errorf("encrypted export client doesn't support switching servers")
+}
+
+func (c *client) SetSessionKey(string) {}
+
+func (c *client) ShareObject(itemID, organizationID string, collectionIDs []string) (*bw.Object, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *client) Status() (*bw.Status, error) {
+	return &bw.Status{Status: bw.StatusUnlocked}, nil
+}
+
+func (c *client) Sync() error {
+	return nil
+}
+
+func (c *client) Unlock(password string) error {
+	return nil
+}
+
+func (c *client) Version() (string, error) {
+	return "", fmt.Errorf("encrypted export client doesn't support retrieving the CLI version")
+}
+
+func (c *client) objects(objType bw.ObjectType) []bw.Object {
+	switch objType {
+	case bw.ObjectTypeItem:
+		return c.items
+	case bw.ObjectTypeFolder:
+		return c.folders
+	case bw.ObjectTypeOrgCollection:
+		return c.collections
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package encryptedexport
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto/keybuilder"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPassword = "export-password"
+	testSalt     = "MTIzNDU2Nzg5MDEyMzQ1Ng=="
+)
+
+var testExport = `{
+  "encrypted": false,
+  "folders": [{"id": "folder-1", "name": "Databases"}],
+  "items": [
+    {"id": "item-1", "folderId": "folder-1", "type": 1, "name": "Postgres", "login": {"username": "admin", "password": "secret", "uris": [{"uri": "https://db.example.com"}]}},
+    {"id": "item-2", "organizationId": "org-1", "collectionIds": ["coll-1"], "type": 2, "name": "Runbook", "notes": "steps"},
+    {"id": "item-3", "type": 1, "name": "Postgres Old", "deletedDate": "2024-01-01T00:00:00.000Z"}
+  ]
+}`
+
+func TestDecryptPBKDF2Export(t *testing.T) {
+	data := encryptTestExport(t, keybuilder.PBKDF2_SHA256, 1000, nil, nil)
+
+	export, err := Decrypt(data, testPassword)
+	if assert.NoError(t, err) {
+		assert.Len(t, export.Items, 3)
+		assert.Equal(t, "secret", export.Items[0].Login.Password)
+		assert.Equal(t, "Databases", export.Folders[0].Name)
+	}
+}
+
+func TestDecryptArgon2Export(t *testing.T) {
+	memory, parallelism := 16, 2
+	data := encryptTestExport(t, keybuilder.ARGON2ID, 2, &memory, &parallelism)
+
+	export, err := Decrypt(data, testPassword)
+	if assert.NoError(t, err) {
+		assert.Len(t, export.Items, 3)
+	}
+}
+
+func TestDecryptExportWithWrongPassword(t *testing.T) {
+	data := encryptTestExport(t, keybuilder.PBKDF2_SHA256, 1000, nil, nil)
+
+	_, err := Decrypt(data, "wrong-password")
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+}
+
+func TestDecryptUnprotectedExport(t *testing.T) {
+	_, err := Decrypt([]byte(testExport), testPassword)
+	assert.ErrorIs(t, err, ErrNotPasswordProtected)
+}
+
+func TestListObjectsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.json")
+	err := os.WriteFile(path, encryptTestExport(t, keybuilder.PBKDF2_SHA256, 1000, nil, nil), 0600)
+	assert.NoError(t, err)
+
+	c, err := NewClientFromFile(path, testPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	testCases := []struct {
+		options     []bw.ListObjectsOption
+		expectedIDs []string
+	}{
+		{
+			options:     []bw.ListObjectsOption{bw.WithSearch("postgres")},
+			expectedIDs: []string{"item-1"},
+		},
+		{
+			options:     []bw.ListObjectsOption{bw.WithFolderID("folder-1")},
+			expectedIDs: []string{"item-1"},
+		},
+		{
+			options:     []bw.ListObjectsOption{bw.WithOrganizationID("org-1"), bw.WithCollectionID("coll-1")},
+			expectedIDs: []string{"item-2"},
+		},
+		{
+			options:     []bw.ListObjectsOption{bw.WithUrl("https://eu.db.example.com/login")},
+			expectedIDs: []string{"item-1"},
+		},
+		{
+			options:     []bw.ListObjectsOption{bw.WithSearch("unknown")},
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run("", func(t *testing.T) {
+			objs, err := c.ListObjects("items", test.options...)
+			assert.NoError(t, err)
+
+			ids := []string{}
+			for _, obj := range objs {
+				assert.Equal(t, bw.ObjectTypeItem, obj.Object)
+				ids = append(ids, obj.ID)
+			}
+			assert.Equal(t, test.expectedIDs, ids)
+		})
+	}
+
+	obj, err := c.GetObject(bw.Object{ID: "folder-1", Object: bw.ObjectTypeFolder})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Databases", obj.Name)
+	}
+
+	_, err = c.GetObject(bw.Object{ID: "folder-2", Object: bw.ObjectTypeFolder})
+	assert.ErrorIs(t, err, bw.ErrObjectNotFound)
+}
+
+func encryptTestExport(t *testing.T, kdf, iterations int, memory, parallelism *int) []byte {
+	m, p := 0, 0
+	if memory != nil {
+		m, p = *memory, *parallelism
+	}
+
+	key, err := keybuilder.BuildPasswordProtectedExportKey(testPassword, testSalt, kdf, iterations, m, p)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	validation, err := crypto.Encrypt([]byte("a0e1b2c3-d4e5-4f60-8a7b-9c0d1e2f3a4b"), *key)
+	assert.NoError(t, err)
+
+	encData, err := crypto.Encrypt([]byte(testExport), *key)
+	assert.NoError(t, err)
+
+	out, err := json.Marshal(EncryptedExport{
+		Encrypted:         true,
+		PasswordProtected: true,
+		Salt:              testSalt,
+		KdfType:           kdf,
+		KdfIterations:     iterations,
+		KdfMemory:         memory,
+		KdfParallelism:    parallelism,
+		EncKeyValidation:  validation,
+		Data:              encData,
+	})
+	assert.NoError(t, err)
+	return out
+}
@@ -0,0 +1,78 @@
+package encryptedexport
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/urimatch"
+)
+
+// filterObjects applies the same filters as the Bitwarden CLI would. The
+// options are rendered as query parameters, like for the REST client.
+func filterObjects(objs []bw.Object, options ...bw.ListObjectsOption) []bw.Object {
+	q := url.Values{}
+	for _, opt := range options {
+		opt(nil, &q)
+	}
+
+	filtered := make([]bw.Object, 0, len(objs))
+	for _, obj := range objs {
+		if obj.DeletedDate != nil {
+			continue
+		}
+		if v := q.Get("folderid"); len(v) > 0 && obj.FolderID != v {
+			continue
+		}
+		if v := q.Get("collectionId"); len(v) > 0 && !slices.Contains(obj.CollectionIds, v) {
+			continue
+		}
+		if v := q.Get("organizationId"); len(v) > 0 && obj.OrganizationID != v {
+			continue
+		}
+		if v := q.Get("search"); len(v) > 0 && !matchesSearch(obj, v) {
+			continue
+		}
+		if v := q.Get("url"); len(v) > 0 && !matchesURL(obj, v) {
+			continue
+		}
+		filtered = append(filtered, obj)
+	}
+	return filtered
+}
+
+// matchesSearch mimics the basic search of the Bitwarden CLI, which looks
+// at the name, the beginning of the identifier, the username and the URIs.
+func matchesSearch(obj bw.Object, search string) bool {
+	search = strings.ToLower(strings.TrimSpace(search))
+
+	if strings.Contains(strings.ToLower(obj.Name), search) {
+		return true
+	}
+	if len(search) >= 8 && strings.HasPrefix(obj.ID, search) {
+		return true
+	}
+	if strings.Contains(strings.ToLower(obj.Login.Username), search) {
+		return true
+	}
+	for _, uri := range obj.Login.URIs {
+		if strings.Contains(strings.ToLower(uri.URI), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesURL returns true if Bitwarden clients would autofill one of the
+// login URIs on the given URL, honoring their match detection mode. URIs with
+// an invalid regular expression never match.
+func matchesURL(obj bw.Object, rawURL string) bool {
+	matcher := urimatch.New(bw.URIMatchBaseDomain)
+	for _, uri := range obj.Login.URIs {
+		if matches, err := matcher.Matches(uri, rawURL); err == nil && matches {
+			return true
+		}
+	}
+	return false
+}
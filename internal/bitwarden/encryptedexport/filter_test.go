@@ -0,0 +1,25 @@
+package encryptedexport
+
+import (
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterObjectsByURL(t *testing.T) {
+	never, exact, regexp := bw.URIMatchNever, bw.URIMatchExact, bw.URIMatchRegExp
+	objs := []bw.Object{
+		{ID: "item-1", Login: bw.Login{URIs: []bw.LoginURI{{URI: "https://db.example.com"}}}},
+		{ID: "item-2", Login: bw.Login{URIs: []bw.LoginURI{{Match: &never, URI: "https://eu.db.example.com"}}}},
+		{ID: "item-3", Login: bw.Login{URIs: []bw.LoginURI{{Match: &exact, URI: "https://eu.db.example.com"}}}},
+		{ID: "item-4", Login: bw.Login{URIs: []bw.LoginURI{{Match: &regexp, URI: "https://(eu"}, {Match: &regexp, URI: `^https://eu\.db\.`}}}},
+		{ID: "item-5", Login: bw.Login{URIs: []bw.LoginURI{{URI: "https://example.org"}}}},
+	}
+
+	ids := []string{}
+	for _, obj := range filterObjects(objs, bw.WithUrl("https://eu.db.example.com/login")) {
+		ids = append(ids, obj.ID)
+	}
+	assert.Equal(t, []string{"item-1", "item-4"}, ids)
+}
@@ -0,0 +1,26 @@
+package encryptedexport
+
+import (
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+)
+
+// EncryptedExport is the content of a password-protected JSON export.
+type EncryptedExport struct {
+	Encrypted         bool   `json:"encrypted"`
+	PasswordProtected bool   `json:"passwordProtected"`
+	Salt              string `json:"salt"`
+	KdfType           int    `json:"kdfType"`
+	KdfIterations     int    `json:"kdfIterations"`
+	KdfMemory         *int   `json:"kdfMemory,omitempty"`
+	KdfParallelism    *int   `json:"kdfParallelism,omitempty"`
+	EncKeyValidation  string `json:"encKeyValidation_DO_NOT_EDIT"`
+	Data              string `json:"data"`
+}
+
+// Export is the decrypted content of an export.
+type Export struct {
+	Encrypted   bool        `json:"encrypted"`
+	Collections []bw.Object `json:"collections,omitempty"`
+	Folders     []bw.Object `json:"folders,omitempty"`
+	Items       []bw.Object `json:"items,omitempty"`
+}
@@ -0,0 +1,168 @@
+package orgapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bearer"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
+)
+
+/*
+* This is a client for the Bitwarden Public API, which manages an
+* organization on behalf of an organization API key (client_credentials).
+ */
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("organization API key was rejected")
+)
+
+type Client interface {
+	ListCollections() ([]Collection, error)
+	ListEvents(filter EventsFilter, maxPages int) ([]Event, string, error)
+	ListGroups() ([]Group, error)
+	ListMembers() ([]Member, error)
+	ListPolicies() ([]Policy, error)
+	GetPolicy(policyType int) (*Policy, error)
+	UpdatePolicy(policy Policy) (*Policy, error)
+}
+
+func NewClient(ctx context.Context, apiURL, identityURL, clientID, clientSecret string, opts ...Options) Client {
+	c := &client{
+		retryPolicy: retry.DefaultPolicy(),
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	credentials := bearer.Credentials{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scope:        "api.organization",
+		Description:  "the organization API key",
+	}
+	c.api = bearer.NewClient(ctx, "Public API", strings.TrimSuffix(apiURL, "/")+"/public", identityURL, credentials,
+		bearer.WithRetryPolicy(c.retryPolicy),
+		bearer.WithErrors(ErrNotFound, ErrUnauthorized),
+	)
+	return c
+}
+
+type Options func(c *client)
+
+func WithRetryPolicy(policy retry.Policy) Options {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
+}
+
+type client struct {
+	api         *bearer.Client
+	retryPolicy retry.Policy
+}
+
+func (c *client) ListCollections() ([]Collection, error) {
+	return listAll[Collection](c, "collections", nil)
+}
+
+// ListEvents returns the events of the organization matching the filter. When
+// maxPages is reached before the last page, the continuation token of the
+// next page is returned as well.
+func (c *client) ListEvents(filter EventsFilter, maxPages int) ([]Event, string, error) {
+	query := url.Values{}
+	query.Set("start", filter.Start.UTC().Format(time.RFC3339))
+	query.Set("end", filter.End.UTC().Format(time.RFC3339))
+	if len(filter.ActingUserID) > 0 {
+		query.Set("actingUserId", filter.ActingUserID)
+	}
+	if len(filter.ItemID) > 0 {
+		query.Set("itemId", filter.ItemID)
+	}
+	if len(filter.ContinuationToken) > 0 {
+		query.Set("continuationToken", filter.ContinuationToken)
+	}
+
+	return listPages[Event](c, "events", query, maxPages)
+}
+
+func (c *client) ListGroups() ([]Group, error) {
+	return listAll[Group](c, "groups", nil)
+}
+
+func (c *client) ListMembers() ([]Member, error) {
+	return listAll[Member](c, "members", nil)
+}
+
+func (c *client) ListPolicies() ([]Policy, error) {
+	return listAll[Policy](c, "policies", nil)
+}
+
+func (c *client) GetPolicy(policyType int) (*Policy, error) {
+	var policy Policy
+	err := c.doJSON("GET", fmt.Sprintf("policies/%d", policyType), nil, nil, &policy)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (c *client) UpdatePolicy(policy Policy) (*Policy, error) {
+	request := struct {
+		Enabled bool                   `json:"enabled"`
+		Data    map[string]interface{} `json:"data"`
+	}{
+		Enabled: policy.Enabled,
+		Data:    policy.Data,
+	}
+
+	var updated Policy
+	err := c.doJSON("PUT", fmt.Sprintf("policies/%d", policy.Type), nil, request, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// listAll retrieves every page of a list, following continuation tokens.
+func listAll[T any](c *client, path string, query url.Values) ([]T, error) {
+	all, _, err := listPages[T](c, path, query, 0)
+	return all, err
+}
+
+// listPages retrieves up to maxPages pages of a list (all of them if
+// maxPages is 0), and returns the continuation token of the next page if
+// there is one.
+func listPages[T any](c *client, path string, query url.Values, maxPages int) ([]T, string, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	all := []T{}
+	for pages := 1; ; pages++ {
+		var page ListResponse[T]
+		err := c.doJSON("GET", path, query, nil, &page)
+		if err != nil {
+			return nil, "", err
+		}
+
+		all = append(all, page.Data...)
+		if page.ContinuationToken == nil || len(*page.ContinuationToken) == 0 {
+			return all, "", nil
+		}
+		if maxPages > 0 && pages >= maxPages {
+			return all, *page.ContinuationToken, nil
+		}
+		query.Set("continuationToken", *page.ContinuationToken)
+	}
+}
+
+// doJSON sends a request to the Public API and decodes its response into out.
+func (c *client) doJSON(method, path string, query url.Values, in, out interface{}) error {
+	return c.api.DoJSON(method, path, query, in, out)
+}
@@ -0,0 +1,182 @@
+package orgapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testServer struct {
+	*httptest.Server
+
+	tokensIssued  int
+	revokedTokens map[string]bool
+}
+
+func newTestServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request)) *testServer {
+	s := &testServer{revokedTokens: map[string]bool{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/connect/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		if r.PostForm.Get("client_id") != "organization.org-1" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "api.organization", r.PostForm.Get("scope"))
+
+		s.tokensIssued = s.tokensIssued + 1
+		fmt.Fprintf(w, `{"access_token": "token-%d", "expires_in": 3600, "token_type": "Bearer"}`, s.tokensIssued)
+	})
+	mux.HandleFunc("/api/public/", func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")
+		if s.revokedTokens[token] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	})
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *testServer) newClient(clientSecret string) Client {
+	return NewClient(context.Background(), s.URL+"/api", s.URL+"/identity", "organization.org-1", clientSecret)
+}
+
+func TestListMembersFollowsContinuationTokens(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/public/members", r.URL.Path)
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+
+		switch r.URL.Query().Get("continuationToken") {
+		case "":
+			w.Write([]byte(`{"object": "list", "data": [{"id": "member-1", "email": "one@example.com"}], "continuationToken": "page-2"}`))
+		case "page-2":
+			w.Write([]byte(`{"object": "list", "data": [{"id": "member-2", "email": "two@example.com"}], "continuationToken": null}`))
+		}
+	})
+	defer server.Close()
+
+	members, err := server.newClient("secret").ListMembers()
+
+	assert.NoError(t, err)
+	if assert.Len(t, members, 2) {
+		assert.Equal(t, "member-1", members[0].ID)
+		assert.Equal(t, "two@example.com", members[1].Email)
+	}
+	assert.Equal(t, 1, server.tokensIssued)
+}
+
+func TestTokenIsRefreshedWhenRejected(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"object": "list", "data": [{"id": "group-1", "name": "Engineering"}]}`))
+	})
+	defer server.Close()
+
+	client := server.newClient("secret")
+
+	_, err := client.ListGroups()
+	assert.NoError(t, err)
+	_, err = client.ListCollections()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, server.tokensIssued)
+
+	server.revokedTokens["Bearer token-1"] = true
+	groups, err := client.ListGroups()
+	assert.NoError(t, err)
+	assert.Len(t, groups, 1)
+	assert.Equal(t, 2, server.tokensIssued)
+}
+
+func TestInvalidCredentials(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("the Public API shouldn't be called without a token")
+	})
+	defer server.Close()
+
+	_, err := server.newClient("wrong").ListPolicies()
+
+	assert.ErrorContains(t, err, "error logging in with the organization API key")
+	assert.ErrorContains(t, err, "invalid_client")
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"object": "error", "message": "Your organization does not have access to the Public API."}`))
+	})
+	defer server.Close()
+
+	_, err := server.newClient("secret").ListPolicies()
+
+	assert.EqualError(t, err, "error calling 'GET policies': bad status code 400: Your organization does not have access to the Public API.")
+}
+
+func TestListEventsStopsAfterMaxPages(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/public/events", r.URL.Path)
+		assert.Equal(t, "2024-01-01T00:00:00Z", r.URL.Query().Get("start"))
+		assert.Equal(t, "2024-01-31T00:00:00Z", r.URL.Query().Get("end"))
+		assert.Equal(t, "user-1", r.URL.Query().Get("actingUserId"))
+
+		switch r.URL.Query().Get("continuationToken") {
+		case "page-2":
+			w.Write([]byte(`{"object": "list", "data": [{"type": 1107, "itemId": "item-1"}], "continuationToken": "page-3"}`))
+		default:
+			t.Fatalf("unexpected continuation token: '%s'", r.URL.Query().Get("continuationToken"))
+		}
+	})
+	defer server.Close()
+
+	events, next, err := server.newClient("secret").ListEvents(EventsFilter{
+		Start:             time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:               time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		ActingUserID:      "user-1",
+		ContinuationToken: "page-2",
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "page-3", next)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "Cipher_ClientViewed", EventTypeName(events[0].Type))
+	}
+}
+
+func TestUpdatePolicy(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/public/policies/1", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"enabled": true, "data": {"minLength": 14}}`, string(body))
+		w.Write([]byte(`{"object": "policy", "id": "policy-1", "type": 1, "enabled": true, "data": {"minLength": 14}}`))
+	})
+	defer server.Close()
+
+	policy, err := server.newClient("secret").UpdatePolicy(Policy{Type: 1, Enabled: true, Data: map[string]interface{}{"minLength": 14}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "policy-1", policy.ID)
+	assert.Equal(t, float64(14), policy.Data["minLength"])
+}
+
+func TestGetPolicyNotFound(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	_, err := server.newClient("secret").GetPolicy(3)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
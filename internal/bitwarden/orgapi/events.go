@@ -0,0 +1,132 @@
+package orgapi
+
+import "fmt"
+
+// eventTypeNames are the names Bitwarden gives to event types, see
+// https://bitwarden.com/help/event-logs/.
+var eventTypeNames = map[int]string{
+	1000: "User_LoggedIn",
+	1001: "User_ChangedPassword",
+	1002: "User_Updated2fa",
+	1003: "User_Disabled2fa",
+	1004: "User_Recovered2fa",
+	1005: "User_FailedLogIn",
+	1006: "User_FailedLogIn2fa",
+	1007: "User_ClientExportedVault",
+	1008: "User_UpdatedTempPassword",
+	1009: "User_MigratedKeyToKeyConnector",
+	1010: "User_RequestedDeviceApproval",
+	1011: "User_TdeOffboardingPasswordSet",
+
+	1100: "Cipher_Created",
+	1101: "Cipher_Updated",
+	1102: "Cipher_Deleted",
+	1103: "Cipher_AttachmentCreated",
+	1104: "Cipher_AttachmentDeleted",
+	1105: "Cipher_Shared",
+	1106: "Cipher_UpdatedCollections",
+	1107: "Cipher_ClientViewed",
+	1108: "Cipher_ClientToggledPasswordVisible",
+	1109: "Cipher_ClientToggledHiddenFieldVisible",
+	1110: "Cipher_ClientToggledCardCodeVisible",
+	1111: "Cipher_ClientCopiedPassword",
+	1112: "Cipher_ClientCopiedHiddenField",
+	1113: "Cipher_ClientCopiedCardCode",
+	1114: "Cipher_ClientAutofilled",
+	1115: "Cipher_SoftDeleted",
+	1116: "Cipher_Restored",
+	1117: "Cipher_ClientToggledCardNumberVisible",
+
+	1300: "Collection_Created",
+	1301: "Collection_Updated",
+	1302: "Collection_Deleted",
+
+	1400: "Group_Created",
+	1401: "Group_Updated",
+	1402: "Group_Deleted",
+
+	1500: "OrganizationUser_Invited",
+	1501: "OrganizationUser_Confirmed",
+	1502: "OrganizationUser_Updated",
+	1503: "OrganizationUser_Removed",
+	1504: "OrganizationUser_UpdatedGroups",
+	1505: "OrganizationUser_UnlinkedSso",
+	1506: "OrganizationUser_ResetPassword_Enroll",
+	1507: "OrganizationUser_ResetPassword_Withdraw",
+	1508: "OrganizationUser_AdminResetPassword",
+	1509: "OrganizationUser_ResetSsoLink",
+	1510: "OrganizationUser_FirstSsoLogin",
+	1511: "OrganizationUser_Revoked",
+	1512: "OrganizationUser_Restored",
+	1513: "OrganizationUser_ApprovedAuthRequest",
+	1514: "OrganizationUser_RejectedAuthRequest",
+	1515: "OrganizationUser_Deleted",
+	1516: "OrganizationUser_Left",
+
+	1600: "Organization_Updated",
+	1601: "Organization_PurgedVault",
+	1602: "Organization_ClientExportedVault",
+	1603: "Organization_VaultAccessed",
+	1604: "Organization_EnabledSso",
+	1605: "Organization_DisabledSso",
+	1606: "Organization_EnabledKeyConnector",
+	1607: "Organization_DisabledKeyConnector",
+	1608: "Organization_SponsorshipsSynced",
+	1609: "Organization_CollectionManagement_Updated",
+
+	1700: "Policy_Updated",
+
+	1900: "OrganizationDomain_Added",
+	1901: "OrganizationDomain_Removed",
+	1902: "OrganizationDomain_Verified",
+	1903: "OrganizationDomain_NotVerified",
+
+	2000: "Secret_Retrieved",
+}
+
+// deviceTypeNames are the names Bitwarden gives to the clients events
+// originate from.
+var deviceTypeNames = map[int]string{
+	0:  "Android",
+	1:  "iOS",
+	2:  "ChromeExtension",
+	3:  "FirefoxExtension",
+	4:  "OperaExtension",
+	5:  "EdgeExtension",
+	6:  "WindowsDesktop",
+	7:  "MacOsDesktop",
+	8:  "LinuxDesktop",
+	9:  "ChromeBrowser",
+	10: "FirefoxBrowser",
+	11: "OperaBrowser",
+	12: "EdgeBrowser",
+	13: "IEBrowser",
+	14: "UnknownBrowser",
+	15: "AndroidAmazon",
+	16: "UWP",
+	17: "SafariBrowser",
+	18: "VivaldiBrowser",
+	19: "VivaldiExtension",
+	20: "SafariExtension",
+	21: "SDK",
+	22: "Server",
+	23: "WindowsCLI",
+	24: "MacOsCLI",
+	25: "LinuxCLI",
+}
+
+// EventTypeName returns a readable name for an event type.
+func EventTypeName(eventType int) string {
+	if name, ok := eventTypeNames[eventType]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown_%d", eventType)
+}
+
+// DeviceTypeName returns a readable name for a device type.
+func DeviceTypeName(deviceType int) string {
+	if name, ok := deviceTypeNames[deviceType]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown_%d", deviceType)
+}
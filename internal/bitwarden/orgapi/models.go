@@ -0,0 +1,70 @@
+package orgapi
+
+import "time"
+
+type ListResponse[T any] struct {
+	Object            string  `json:"object"`
+	Data              []T     `json:"data"`
+	ContinuationToken *string `json:"continuationToken"`
+}
+
+type AssociationWithPermissions struct {
+	ID            string `json:"id"`
+	ReadOnly      bool   `json:"readOnly"`
+	HidePasswords bool   `json:"hidePasswords"`
+	Manage        bool   `json:"manage"`
+}
+
+type Member struct {
+	ID               string                       `json:"id"`
+	UserID           string                       `json:"userId"`
+	Name             string                       `json:"name"`
+	Email            string                       `json:"email"`
+	Type             int                          `json:"type"`
+	Status           int                          `json:"status"`
+	ExternalID       string                       `json:"externalId"`
+	TwoFactorEnabled bool                         `json:"twoFactorEnabled"`
+	Collections      []AssociationWithPermissions `json:"collections"`
+}
+
+type Group struct {
+	ID          string                       `json:"id"`
+	Name        string                       `json:"name"`
+	ExternalID  string                       `json:"externalId"`
+	Collections []AssociationWithPermissions `json:"collections"`
+}
+
+type Collection struct {
+	ID         string                       `json:"id"`
+	ExternalID string                       `json:"externalId"`
+	Groups     []AssociationWithPermissions `json:"groups"`
+}
+
+type Policy struct {
+	ID      string                 `json:"id,omitempty"`
+	Type    int                    `json:"type"`
+	Enabled bool                   `json:"enabled"`
+	Data    map[string]interface{} `json:"data"`
+}
+
+type Event struct {
+	Type           int        `json:"type"`
+	ItemID         string     `json:"itemId"`
+	CollectionID   string     `json:"collectionId"`
+	GroupID        string     `json:"groupId"`
+	PolicyID       string     `json:"policyId"`
+	MemberID       string     `json:"memberId"`
+	ActingUserID   string     `json:"actingUserId"`
+	InstallationID string     `json:"installationId"`
+	Date           *time.Time `json:"date"`
+	Device         *int       `json:"device"`
+	IPAddress      string     `json:"ipAddress"`
+}
+
+type EventsFilter struct {
+	Start             time.Time
+	End               time.Time
+	ActingUserID      string
+	ItemID            string
+	ContinuationToken string
+}
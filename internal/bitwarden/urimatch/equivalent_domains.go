@@ -0,0 +1,33 @@
+package urimatch
+
+import (
+	_ "embed"
+	"encoding/json"
+	"slices"
+	"sync"
+)
+
+// equivalentDomainsJSON holds groups of the global equivalent domains of
+// Bitwarden, i.e. domains the Bitwarden server considers the same website for
+// every user.
+//
+//go:embed equivalent_domains.json
+var equivalentDomainsJSON []byte
+
+var globalEquivalentDomains = sync.OnceValue(func() [][]string {
+	var groups [][]string
+	if err := json.Unmarshal(equivalentDomainsJSON, &groups); err != nil {
+		panic(err)
+	}
+	return groups
+})
+
+// GlobalEquivalentDomains returns the groups of global equivalent domains.
+func GlobalEquivalentDomains() [][]string {
+	groups := globalEquivalentDomains()
+	clone := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		clone = append(clone, slices.Clone(group))
+	}
+	return clone
+}
@@ -0,0 +1,227 @@
+// Package urimatch implements the URI match detection of Bitwarden clients,
+// i.e. how they decide whether a login item autofills on a given URL.
+package urimatch
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"golang.org/x/net/publicsuffix"
+)
+
+// domainMatchBlacklist lists hosts on which base domain matching is disabled
+// for a domain, as Bitwarden clients do.
+var domainMatchBlacklist = map[string][]string{
+	"google.com": {"script.google.com"},
+}
+
+// Matcher tells whether login URIs match URLs.
+type Matcher struct {
+	// DefaultMatch is used for URIs without match detection mode, like the
+	// 'Default URI match detection' setting of Bitwarden clients.
+	DefaultMatch bw.URIMatch
+
+	// EquivalentDomains are groups of domains considered the same website
+	// when matching on base domains.
+	EquivalentDomains [][]string
+}
+
+// New returns a Matcher using the global equivalent domains of Bitwarden.
+func New(defaultMatch bw.URIMatch) *Matcher {
+	return &Matcher{
+		DefaultMatch:      defaultMatch,
+		EquivalentDomains: GlobalEquivalentDomains(),
+	}
+}
+
+// Matches tells whether a URL matches a login URI. It only fails on invalid
+// regular expressions: URIs that can't be parsed never match, as in Bitwarden
+// clients.
+func (m *Matcher) Matches(uri bw.LoginURI, rawURL string) (bool, error) {
+	match := m.DefaultMatch
+	if uri.Match != nil {
+		match = *uri.Match
+	}
+
+	if len(uri.URI) == 0 {
+		return false, nil
+	}
+
+	switch match {
+	case bw.URIMatchBaseDomain:
+		return m.matchesDomain(uri.URI, rawURL), nil
+
+	case bw.URIMatchHost:
+		uriHost, urlHost := Host(uri.URI), Host(rawURL)
+		return len(uriHost) > 0 && uriHost == urlHost, nil
+
+	case bw.URIMatchStartWith:
+		return strings.HasPrefix(rawURL, uri.URI), nil
+
+	case bw.URIMatchExact:
+		return rawURL == uri.URI, nil
+
+	case bw.URIMatchRegExp:
+		re, err := compileRegexp(uri.URI)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(rawURL), nil
+
+	case bw.URIMatchNever:
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported URI match: %d", match)
+}
+
+// MatchingURI returns the first URI of a login item matching a URL, or nil
+// when the item wouldn't autofill on it. Like in Bitwarden clients, URIs with
+// an invalid regular expression are skipped: they are reported in the error,
+// which can come with a matching URI.
+func (m *Matcher) MatchingURI(login bw.Login, rawURL string) (*bw.LoginURI, error) {
+	var errs []error
+	for _, uri := range login.URIs {
+		matches, err := m.Matches(uri, rawURL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if matches {
+			return &uri, errors.Join(errs...)
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (m *Matcher) matchesDomain(uri, rawURL string) bool {
+	urlDomain := BaseDomain(rawURL)
+	uriDomain := BaseDomain(uri)
+	if len(urlDomain) == 0 || len(uriDomain) == 0 {
+		return false
+	}
+
+	for _, host := range domainMatchBlacklist[uriDomain] {
+		if hostname(rawURL) == host {
+			return false
+		}
+	}
+
+	if uriDomain == urlDomain {
+		return true
+	}
+	for _, group := range m.EquivalentDomains {
+		if slices.Contains(group, urlDomain) && slices.Contains(group, uriDomain) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRegexp checks that a URI can be used with regular expression
+// match detection.
+func ValidateRegexp(uri string) error {
+	_, err := compileRegexp(uri)
+	return err
+}
+
+// IsUnsupportedSyntax tells whether a regular expression error is caused by
+// Perl syntax RE2 doesn't support, like lookarounds or backreferences, which
+// Bitwarden clients accept as they use JavaScript regular expressions.
+func IsUnsupportedSyntax(err error) bool {
+	var syntaxErr *syntax.Error
+	if !errors.As(err, &syntaxErr) {
+		return false
+	}
+
+	switch syntaxErr.Code {
+	case syntax.ErrInvalidPerlOp:
+		return true
+	case syntax.ErrInvalidNamedCapture:
+		// Lookbehinds are parsed as named captures.
+		return strings.HasPrefix(syntaxErr.Expr, "(?<=") || strings.HasPrefix(syntaxErr.Expr, "(?<!")
+	case syntax.ErrInvalidEscape:
+		// Numbered and named backreferences.
+		return len(syntaxErr.Expr) == 2 && (syntaxErr.Expr[1] >= '1' && syntaxErr.Expr[1] <= '9' || syntaxErr.Expr[1] == 'k')
+	}
+	return false
+}
+
+// compileRegexp compiles a URI the way Bitwarden clients do, i.e. as a case
+// insensitive regular expression.
+func compileRegexp(uri string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("(?i)" + uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression '%s': %w", uri, err)
+	}
+	return re, nil
+}
+
+// BaseDomain returns the registrable domain of a URI, e.g. 'example.co.uk'
+// for 'https://www.example.co.uk'. Like Bitwarden clients, only the ICANN
+// section of the public suffix list is used, and IP addresses, localhost and
+// hosts without registrable domain are returned as is.
+func BaseDomain(uri string) string {
+	host := hostname(uri)
+	if len(host) == 0 || host == "localhost" || net.ParseIP(host) != nil {
+		return host
+	}
+
+	suffix := icannPublicSuffix(host)
+	if host == suffix {
+		return host
+	}
+	rest := strings.TrimSuffix(host, "."+suffix)
+	return rest[strings.LastIndex(rest, ".")+1:] + "." + suffix
+}
+
+// Host returns the host of a URI, including its port.
+func Host(uri string) string {
+	u := parse(uri)
+	if u == nil {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
+// icannPublicSuffix returns the public suffix of a domain, ignoring the
+// private domains of the public suffix list (e.g. 'github.io').
+func icannPublicSuffix(domain string) string {
+	suffix, icann := publicsuffix.PublicSuffix(domain)
+	for !icann {
+		_, parent, found := strings.Cut(suffix, ".")
+		if !found {
+			break
+		}
+		suffix, icann = publicsuffix.PublicSuffix(parent)
+	}
+	return suffix
+}
+
+func hostname(uri string) string {
+	u := parse(uri)
+	if u == nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// parse parses a URI the way Bitwarden clients do, which assume URIs without
+// scheme are websites.
+func parse(uri string) *url.URL {
+	if !strings.Contains(uri, "://") {
+		uri = "http://" + uri
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil
+	}
+	return u
+}
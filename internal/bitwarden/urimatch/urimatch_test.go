@@ -0,0 +1,156 @@
+package urimatch
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseDomain(t *testing.T) {
+	testCases := map[string]string{
+		"https://www.example.co.uk/login": "example.co.uk",
+		"example.com":                     "example.com",
+		"https://user.github.io":          "github.io",
+		"https://a.b.blogspot.co.uk":      "blogspot.co.uk",
+		"http://192.168.1.1:8080":         "192.168.1.1",
+		"http://localhost:3000":           "localhost",
+		"https://intranet":                "intranet",
+		"https://co.uk":                   "co.uk",
+		"":                                "",
+	}
+
+	for uri, expected := range testCases {
+		t.Run(uri, func(t *testing.T) {
+			assert.Equal(t, expected, BaseDomain(uri))
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	testCases := []struct {
+		match    bw.URIMatch
+		uri      string
+		url      string
+		expected bool
+	}{
+		{match: bw.URIMatchBaseDomain, uri: "https://example.co.uk", url: "https://login.example.co.uk/path", expected: true},
+		{match: bw.URIMatchBaseDomain, uri: "example.co.uk", url: "https://other.co.uk", expected: false},
+		{match: bw.URIMatchBaseDomain, uri: "https://accounts.google.com", url: "https://www.youtube.com", expected: true},
+		{match: bw.URIMatchBaseDomain, uri: "https://accounts.google.com", url: "https://script.google.com", expected: false},
+		{match: bw.URIMatchBaseDomain, uri: "https://amazon.com", url: "https://www.amazon.co.uk", expected: true},
+		{match: bw.URIMatchBaseDomain, uri: "https://amazon.com", url: "https://www.apple.com", expected: false},
+		{match: bw.URIMatchHost, uri: "https://Example.com:8443", url: "https://example.com:8443/login", expected: true},
+		{match: bw.URIMatchHost, uri: "https://example.com:8443", url: "https://example.com/login", expected: false},
+		{match: bw.URIMatchStartWith, uri: "https://example.com/app", url: "https://example.com/app/login", expected: true},
+		{match: bw.URIMatchExact, uri: "https://example.com/login", url: "https://example.com/login?next=/", expected: false},
+		{match: bw.URIMatchRegExp, uri: `^https://[a-z]+\.example\.com/`, url: "https://APP.example.com/", expected: true},
+		{match: bw.URIMatchNever, uri: "https://example.com", url: "https://example.com", expected: false},
+	}
+
+	matcher := New(bw.URIMatchBaseDomain)
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%d %s %s", test.match, test.uri, test.url), func(t *testing.T) {
+			matches, err := matcher.Matches(bw.LoginURI{Match: &test.match, URI: test.uri}, test.url)
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, matches)
+			}
+		})
+	}
+}
+
+func TestMatchesDefaultMatch(t *testing.T) {
+	uri := bw.LoginURI{URI: "https://example.com"}
+
+	matches, err := New(bw.URIMatchBaseDomain).Matches(uri, "https://www.example.com/login")
+	assert.NoError(t, err)
+	assert.True(t, matches)
+
+	matches, err = New(bw.URIMatchHost).Matches(uri, "https://www.example.com/login")
+	assert.NoError(t, err)
+	assert.False(t, matches)
+}
+
+func TestMatchesInvalidRegexp(t *testing.T) {
+	match := bw.URIMatchRegExp
+	_, err := New(bw.URIMatchBaseDomain).Matches(bw.LoginURI{Match: &match, URI: "https://(example.com"}, "https://example.com")
+	assert.ErrorContains(t, err, "invalid regular expression 'https://(example.com'")
+
+	assert.NoError(t, ValidateRegexp(`^https://example\.com/`))
+	assert.Error(t, ValidateRegexp("https://(example.com"))
+}
+
+func TestIsUnsupportedSyntax(t *testing.T) {
+	testCases := map[string]bool{
+		`^https://(?!admin\.)[a-z]+\.example\.com/`: true,
+		`^https://(?=www\.)`:                        true,
+		`(?<=https://)example\.com`:                 true,
+		`(?<!admin\.)example\.com`:                  true,
+		`^https://([a-z]+)\.\1\.com`:                true,
+		`^https://(?<sub>[a-z]+)\.\k<sub>\.com`:     true,
+		`https://(example.com`:                      false,
+		`https://example.com)`:                      false,
+		`https://[example.com`:                      false,
+		`https://example.com**`:                     false,
+		`https://example.com\`:                      false,
+		`https://(?<sub>example.com`:                false,
+	}
+
+	for uri, unsupported := range testCases {
+		err := ValidateRegexp(uri)
+		if assert.Error(t, err, uri) {
+			assert.Equal(t, unsupported, IsUnsupportedSyntax(err), uri)
+		}
+	}
+	assert.False(t, IsUnsupportedSyntax(errors.New("unsupported")))
+}
+
+func TestMatchingURI(t *testing.T) {
+	never, exact := bw.URIMatchNever, bw.URIMatchExact
+	login := bw.Login{
+		URIs: []bw.LoginURI{
+			{Match: &never, URI: "https://example.com"},
+			{Match: &exact, URI: "https://example.com/other"},
+			{URI: "https://www.example.com"},
+		},
+	}
+
+	uri, err := New(bw.URIMatchBaseDomain).MatchingURI(login, "https://example.com/login")
+	if assert.NoError(t, err) && assert.NotNil(t, uri) {
+		assert.Equal(t, "https://www.example.com", uri.URI)
+	}
+
+	uri, err = New(bw.URIMatchBaseDomain).MatchingURI(login, "https://example.org")
+	assert.NoError(t, err)
+	assert.Nil(t, uri)
+}
+
+func TestMatchingURISkipsInvalidRegexp(t *testing.T) {
+	regexp := bw.URIMatchRegExp
+	login := bw.Login{
+		URIs: []bw.LoginURI{
+			{Match: &regexp, URI: "https://(example.com"},
+			{URI: "https://example.com"},
+		},
+	}
+
+	uri, err := New(bw.URIMatchBaseDomain).MatchingURI(login, "https://www.example.com")
+	assert.ErrorContains(t, err, "invalid regular expression 'https://(example.com'")
+	if assert.NotNil(t, uri) {
+		assert.Equal(t, "https://example.com", uri.URI)
+	}
+
+	uri, err = New(bw.URIMatchBaseDomain).MatchingURI(login, "https://example.org")
+	assert.Error(t, err)
+	assert.Nil(t, uri)
+}
+
+func TestGlobalEquivalentDomains(t *testing.T) {
+	groups := GlobalEquivalentDomains()
+	assert.Contains(t, groups, []string{"apple.com", "icloud.com"})
+
+	groups[0][0] = "modified.com"
+	assert.NotEqual(t, "modified.com", GlobalEquivalentDomains()[0][0])
+}
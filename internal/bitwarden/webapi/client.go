@@ -9,10 +9,12 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto"
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto/keybuilder"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
 )
 
 /*
@@ -24,7 +26,7 @@ import (
 type Client interface {
 	CreateOrganization(name, label, billingEmail string) (string, error)
 	GetCollections(orgID string) (string, error)
-	Login(username, password string, kdfIterations int) error
+	Login(username, password string, kdfIterations int, options ...LoginOption) error
 	RegisterUser(name, username, password string, kdfIterations int) error
 }
 
@@ -33,23 +35,102 @@ type session struct {
 	privateKey  *rsa.PrivateKey
 }
 
-func NewClient(serverURL string) Client {
-	return &client{
+func NewClient(serverURL string, opts ...Options) Client {
+	serverURL = strings.TrimSuffix(serverURL, "/")
+	c := &client{
+		apiURL:           fmt.Sprintf("%s/api", serverURL),
 		deviceIdentifier: "5d90b470-5d1d-452d-935c-b730c177a8d6",
 		deviceName:       "firefox",
 		deviceType:       "10",
-		serverURL:        serverURL,
+		httpClient:       &http.Client{},
+		identityURL:      fmt.Sprintf("%s/identity", serverURL),
+		retryPolicy:      retry.DefaultPolicy(),
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+type Options func(c *client)
+
+type LoginOption func(form *url.Values)
+
+// WithTwoFactor provides the token of a two-step login provider, e.g. the
+// code of an authenticator app (provider 0).
+func WithTwoFactor(provider int, token string) LoginOption {
+	return func(form *url.Values) {
+		form.Set("twoFactorProvider", strconv.Itoa(provider))
+		form.Set("twoFactorToken", token)
+		form.Set("twoFactorRemember", "0")
+	}
+}
+
+// WithServiceURLs overrides the URLs of the API and identity services, for
+// deployments which don't serve them under the server URL.
+func WithServiceURLs(apiURL, identityURL string) Options {
+	return func(c *client) {
+		if len(apiURL) > 0 {
+			c.apiURL = strings.TrimSuffix(apiURL, "/")
+		}
+		if len(identityURL) > 0 {
+			c.identityURL = strings.TrimSuffix(identityURL, "/")
+		}
+	}
+}
+
+func WithRetryPolicy(policy retry.Policy) Options {
+	return func(c *client) {
+		c.retryPolicy = policy
 	}
 }
 
 type client struct {
+	apiURL           string
 	deviceIdentifier string
 	deviceName       string
 	deviceType       string
-	serverURL        string
+	httpClient       *http.Client
+	identityURL      string
+	retryPolicy      retry.Policy
 	session          session
 }
 
+// do sends the request, retrying it according to the client's retry policy
+// on transient network errors, throttling and server-side errors. POST
+// requests are only retried when they didn't reach the server, as they could
+// otherwise create objects twice.
+func (c *client) do(req *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy
+	if req.Method == http.MethodPost {
+		policy = policy.ForNonIdempotent()
+	}
+
+	attempt := 0
+	return retry.Do(policy, func() (*http.Response, error) {
+		attempt = attempt + 1
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		err = retry.CheckResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	})
+}
+
 func (c *client) RegisterUser(name, username, password string, kdfIterations int) error {
 	preloginKey, err := keybuilder.BuildPreloginKey(password, username, kdfIterations)
 	if err != nil {
@@ -85,7 +166,13 @@ func (c *client) RegisterUser(name, username, password string, kdfIterations int
 		return fmt.Errorf("unable to marshall user registration request: %w", err)
 	}
 
-	resp, err := http.Post(c.signupURL(), "application/json", bytes.NewBuffer(signupRequestBytes))
+	req, err := http.NewRequest("POST", c.signupURL(), bytes.NewBuffer(signupRequestBytes))
+	if err != nil {
+		return fmt.Errorf("error preparing user registration request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error calling user registration: %w", err)
 	}
@@ -102,7 +189,7 @@ func (c *client) RegisterUser(name, username, password string, kdfIterations int
 	return nil
 }
 
-func (c *client) Login(username, password string, kdfIterations int) error {
+func (c *client) Login(username, password string, kdfIterations int, options ...LoginOption) error {
 	preloginKey, err := keybuilder.BuildPreloginKey(password, username, kdfIterations)
 	if err != nil {
 		return fmt.Errorf("error building prelogin key: %w", err)
@@ -119,6 +206,9 @@ func (c *client) Login(username, password string, kdfIterations int) error {
 	form.Add("device_type", c.deviceType)
 	form.Add("device_identifier", c.deviceIdentifier)
 	form.Add("device_name", c.deviceName)
+	for _, applyOption := range options {
+		applyOption(&form)
+	}
 
 	req, err := http.NewRequest("POST", c.loginURL(), strings.NewReader(form.Encode()))
 	if err != nil {
@@ -129,8 +219,7 @@ func (c *client) Login(username, password string, kdfIterations int) error {
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
 	req.Header.Add("device-type", c.deviceType)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error calling user login: %w", err)
 	}
@@ -208,8 +297,7 @@ func (c *client) CreateOrganization(organizationName string, label string, billi
 	req.Header.Add("Content-Type", "application/json; charset=utf-8")
 	req.Header.Add("device-type", c.deviceType)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("error calling organization creation: %w", err)
 	}
@@ -242,8 +330,7 @@ func (c *client) GetCollections(orgID string) (string, error) {
 	req.Header.Add("Content-Type", "application/json; charset=utf-8")
 	req.Header.Add("device-type", c.deviceType)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("error calling collection retrieval: %w", err)
 	}
@@ -267,9 +354,9 @@ func (c *client) GetCollections(orgID string) (string, error) {
 	return collResponse.Data[0].Id, nil
 }
 
-func (c *client) signupURL() string       { return fmt.Sprintf("%s/api/accounts/register", c.serverURL) }
-func (c *client) loginURL() string        { return fmt.Sprintf("%s/identity/connect/token", c.serverURL) }
-func (c *client) organizationURL() string { return fmt.Sprintf("%s/api/organizations", c.serverURL) }
+func (c *client) signupURL() string       { return fmt.Sprintf("%s/accounts/register", c.apiURL) }
+func (c *client) loginURL() string        { return fmt.Sprintf("%s/connect/token", c.identityURL) }
+func (c *client) organizationURL() string { return fmt.Sprintf("%s/organizations", c.apiURL) }
 func (c *client) organizationCollectionURL(orgID string) string {
-	return fmt.Sprintf("%s/api/organizations/%s/collections", c.serverURL, orgID)
+	return fmt.Sprintf("%s/organizations/%s/collections", c.apiURL, orgID)
 }
@@ -40,8 +40,13 @@ func aes256Encode(plainText []byte, key []byte, iv []byte, blockSize int) ([]byt
 
 func pkcs5Unpadding(src []byte, blockSize int) ([]byte, error) {
 	srcLen := len(src)
+	if srcLen == 0 {
+		return nil, fmt.Errorf("bad padding size")
+	}
+
+	// Empty values are made of a single block of padding.
 	paddingLen := int(src[srcLen-1])
-	if paddingLen >= srcLen || paddingLen > blockSize {
+	if paddingLen == 0 || paddingLen > srcLen || paddingLen > blockSize {
 		return nil, fmt.Errorf("bad padding size")
 	}
 	return src[:srcLen-paddingLen], nil
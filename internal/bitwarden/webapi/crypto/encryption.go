@@ -86,6 +86,15 @@ func DecryptEncryptionKey(encryptedKeyStr string, key symmetrickey.Key) (*symmet
 	return encryptionKey, nil
 }
 
+func Decrypt(encryptedValue string, key symmetrickey.Key) ([]byte, error) {
+	encString, err := encryptedstring.NewFromEncryptedValue(encryptedValue)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing encrypted value: %w", err)
+	}
+
+	return decrypt(encString, &key)
+}
+
 func decrypt(encString *encryptedstring.EncryptedString, key *symmetrickey.Key) ([]byte, error) {
 	if encString.Key.EncryptionType == symmetrickey.AesCbc128_HmacSha256_B64 && key.EncryptionType == symmetrickey.AesCbc256_B64 {
 		return nil, fmt.Errorf("unsupported old scheme")
@@ -41,3 +41,15 @@ func TestDecryptPrivateKey(t *testing.T) {
 	base64PublicKey := base64.StdEncoding.EncodeToString(publicKeyBytes)
 	assert.Equal(t, "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAzfZx4rRpKBVnhiqZe5IH5mRvHjY1iTrZOpooma8PtOIoIdtSRY5YdeX4Hben09C8jZODgyPtxVbWZv/YBS9okE6gPsqugDMQ5M+t7hp3ye9art7CkfvIDjGHZMrANQCYB/tPWkda7jaaAIBkCIPM4+vZ7afBN3Mq/BX7hotSaGlPPP7DCkzbKK/f5U/F/dA8UTZFXtST9ivRWWI8bHdjNwe6Zm2wGUT29zcDmkFq5FqvtY5AuQ6yhuOjXwS1vLP1ckXSJePz0TJNDITW5UmSRI/tesjvnbsq+D/NcerrOvuF0xzKkXlm/lMYq2n3EgQ7neWCCQCrKiQcY9BdhsFEqwIDAQAB", base64PublicKey)
 }
+
+func TestEncryptDecryptEmptyValue(t *testing.T) {
+	encryptionKey, err := symmetrickey.NewFromRawBytes(testEncryptionKey)
+	assert.NoError(t, err)
+
+	encrypted, err := Encrypt([]byte{}, *encryptionKey)
+	assert.NoError(t, err)
+
+	decrypted, err := Decrypt(encrypted, *encryptionKey)
+	assert.NoError(t, err)
+	assert.Empty(t, decrypted)
+}
@@ -0,0 +1,34 @@
+package keybuilder
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto/symmetrickey"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// BuildPasswordProtectedExportKey derives the key protecting a password-protected
+// JSON export, using the KDF settings and salt stored in the export itself.
+func BuildPasswordProtectedExportKey(password, salt string, kdf, iterations, memory, parallelism int) (*symmetrickey.Key, error) {
+	var rawKey []byte
+	switch kdf {
+	case PBKDF2_SHA256:
+		rawKey = pbkdf2.Key([]byte(password), []byte(salt), iterations, 32, sha256.New)
+	case ARGON2ID:
+		if memory <= 0 || parallelism <= 0 {
+			return nil, fmt.Errorf("invalid argon2id parameters (memory: %d, parallelism: %d)", memory, parallelism)
+		}
+		saltHash := sha256.Sum256([]byte(salt))
+		rawKey = argon2.IDKey([]byte(password), saltHash[:], uint32(iterations), uint32(memory*1024), uint8(parallelism), 32)
+	default:
+		return nil, fmt.Errorf("unsupported kdf type: %d", kdf)
+	}
+
+	key, err := symmetrickey.NewFromRawBytes(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("error building export key: %w", err)
+	}
+	return key.StretchKey()
+}
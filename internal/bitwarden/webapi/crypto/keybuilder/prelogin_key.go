@@ -9,6 +9,7 @@ import (
 
 const (
 	PBKDF2_SHA256 = 0
+	ARGON2ID      = 1
 )
 
 func BuildPreloginKey(masterPassword, email string, kdfIteration int) (*symmetrickey.Key, error) {
@@ -5,6 +5,8 @@ import (
 	"io"
 	"log"
 	"os/exec"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/redact"
 )
 
 type NewFn func(binary string, args ...string) Command
@@ -43,7 +45,12 @@ func (c *command) WithStdin(dir string) Command {
 }
 
 func (c *command) Run() ([]byte, error) {
-	log.Printf("[DEBUG] Running command '%v'\n", c.args)
+	// Arguments and outputs may contain secrets, so we only ever log or
+	// return redacted versions of them.
+	secrets := redact.EnvSecrets(c.env)
+	redactedArgs := redact.Args(c.args)
+
+	log.Printf("[DEBUG] Running command '%v'\n", redactedArgs)
 	var stdOut, stdErr bytes.Buffer
 
 	cmd := exec.Command(c.binary, c.args...)
@@ -54,13 +61,16 @@ func (c *command) Run() ([]byte, error) {
 
 	err := cmd.Run()
 	if err != nil {
-		log.Printf("[ERROR] Command '%v' finished with error: %v\n", c.args, err)
-		log.Printf("[ERROR] Stdout: %v\n", stdOut.String())
-		log.Printf("[ERROR] Stderr: %v\n", stdErr.String())
+		redactedStdout := redact.String(redact.JSON(stdOut.Bytes()), secrets...)
+		redactedStderr := redact.String(stdErr.String(), secrets...)
+
+		log.Printf("[ERROR] Command '%v' finished with error: %v\n", redactedArgs, err)
+		log.Printf("[ERROR] Stdout: %v\n", redactedStdout)
+		log.Printf("[ERROR] Stderr: %v\n", redactedStderr)
 
-		return nil, NewError(err, c.args, stdOut.String(), stdErr.String())
+		return nil, NewError(err, redactedArgs, redactedStdout, redactedStderr)
 	}
-	log.Printf("[DEBUG] Command '%v' finished with success\n", c.args)
+	log.Printf("[DEBUG] Command '%v' finished with success\n", redactedArgs)
 
 	return stdOut.Bytes(), nil
 }
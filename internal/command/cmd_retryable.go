@@ -7,7 +7,7 @@ import (
 
 type RetryHandler interface {
 	IsRetryable(err error, attempts int) bool
-	Backoff(attempt int) time.Duration
+	Backoff(err error, attempt int) time.Duration
 }
 
 func NewWithRetries(retryHandler RetryHandler) NewFn {
@@ -41,7 +41,7 @@ func (c *retryableCommand) Run() ([]byte, error) {
 		if err == nil || !c.retryHandler.IsRetryable(err, attempts) {
 			return out, err
 		}
-		c.retryHandler.Backoff(attempts)
+		c.retryHandler.Backoff(err, attempts)
 		log.Printf("[ERROR] Retrying command after error: %v\n", err)
 	}
 }
@@ -55,6 +55,6 @@ func (r *testRetryHandler) IsRetryable(err error, attempt int) bool {
 	return strings.Contains(err.Error(), "failing on purpose") && attempt < 3
 }
 
-func (r *testRetryHandler) Backoff(attempt int) time.Duration {
+func (r *testRetryHandler) Backoff(err error, attempt int) time.Duration {
 	return 0
 }
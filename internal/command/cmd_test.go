@@ -0,0 +1,39 @@
+package command
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandRedactsSecretsFromLogs(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") == "1" {
+		fmt.Println(`{"login":{"password":"item-password"}}`)
+		fmt.Fprintf(os.Stderr, "failed with %s\n", os.Getenv("BW_PASSWORD"))
+		os.Exit(1)
+		return
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	payload := base64.StdEncoding.EncodeToString([]byte(`{"login":{"password":"payload-password"}}`))
+	cmd := New(os.Args[0], "-test.run=TestCommandRedactsSecretsFromLogs", payload)
+	cmd.AppendEnv([]string{"GO_WANT_HELPER_PROCESS=1", "BW_PASSWORD=master-password"})
+
+	_, err := cmd.Run()
+
+	assert.Error(t, err)
+	for _, output := range []string{logs.String(), err.Error()} {
+		assert.Contains(t, output, "<redacted>")
+		assert.NotContains(t, output, payload)
+		assert.NotContains(t, output, "item-password")
+		assert.NotContains(t, output, "master-password")
+	}
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+)
+
+const (
+	CommandName = "generate"
+)
+
+// Run parses the arguments of the 'generate' subcommand and writes the
+// generated configuration to 'w', or to the file given with '--output'.
+func Run(ctx context.Context, args []string, w io.Writer) error {
+	var opts Options
+	var apiEndpoint, bwExecutable, output, vaultPath string
+
+	flags := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flags.StringVar(&opts.Folder, "folder", "", "only generate configuration for items in this folder (name or identifier)")
+	flags.StringVar(&opts.Collection, "collection", "", "only generate configuration for items in this collection (name or identifier)")
+	flags.StringVar(&output, "output", "", "file to write the configuration to (default: stdout)")
+	flags.StringVar(&vaultPath, "vault-path", envOrDefault("BITWARDENCLI_APPDATA_DIR", ".bitwarden/"), "directory of the local Vault, which must already be unlocked with BW_SESSION")
+	flags.StringVar(&bwExecutable, "bw-executable", envOrDefault("BW_EXECUTABLE", "bw"), "name or path of the Bitwarden CLI executable")
+	flags.StringVar(&apiEndpoint, "api-endpoint", "", "Bitwarden CLI API endpoint ('bw serve') to use instead of the Bitwarden CLI")
+	flags.SetOutput(os.Stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: terraform-provider-bitwarden %s [options]\n\n", CommandName)
+		fmt.Fprintf(flags.Output(), "Generates Terraform configuration and import blocks for existing Vault objects.\n\n")
+		flags.PrintDefaults()
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(ctx, apiEndpoint, bwExecutable, vaultPath)
+	if err != nil {
+		return err
+	}
+
+	if len(output) > 0 {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return Generate(w, client, opts)
+}
+
+func newClient(ctx context.Context, apiEndpoint, executable, vaultPath string) (bw.Client, error) {
+	if len(apiEndpoint) > 0 {
+		return bw.NewRestClient(ctx, apiEndpoint), nil
+	}
+
+	sessionKey := os.Getenv("BW_SESSION")
+	if len(sessionKey) == 0 {
+		return nil, fmt.Errorf("BW_SESSION must be set to the session key of an unlocked Vault")
+	}
+
+	abs, err := filepath.Abs(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	bwExecutable, err := exec.LookPath(executable)
+	if err != nil {
+		return nil, err
+	}
+
+	client := bw.NewClient(bwExecutable, bw.WithAppDataDir(abs))
+	client.SetSessionKey(sessionKey)
+	return client, nil
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return defaultValue
+}
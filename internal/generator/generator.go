@@ -0,0 +1,318 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	resourceFolder          = "bitwarden_folder"
+	resourceItemLogin       = "bitwarden_item_login"
+	resourceItemSecureNote  = "bitwarden_item_secure_note"
+	resourceOrgCollection   = "bitwarden_org_collection"
+	collectionObjectsPlural = "collections"
+)
+
+var invalidIdentifierChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// Options restricts the objects for which configuration is generated.
+type Options struct {
+	// Folder is the name or identifier of a folder.
+	Folder string
+
+	// Collection is the name or identifier of a collection.
+	Collection string
+}
+
+// Generate writes Terraform configuration and matching 'import {}' blocks
+// for the items matching the options, along with the folders and
+// collections they belong to. Secret values are replaced with references
+// to sensitive variables.
+func Generate(w io.Writer, client bw.Client, opts Options) error {
+	folders, err := client.ListObjects(fmt.Sprintf("%ss", bw.ObjectTypeFolder))
+	if err != nil {
+		return fmt.Errorf("error listing folders: %w", err)
+	}
+
+	collections, err := client.ListObjects(collectionObjectsPlural)
+	if err != nil {
+		return fmt.Errorf("error listing collections: %w", err)
+	}
+
+	filters := []bw.ListObjectsOption{}
+	if len(opts.Folder) > 0 {
+		folder, err := findByNameOrID(folders, opts.Folder)
+		if err != nil {
+			return fmt.Errorf("unable to find folder '%s': %w", opts.Folder, err)
+		}
+		filters = append(filters, bw.WithFolderID(folder.ID))
+	}
+
+	if len(opts.Collection) > 0 {
+		collection, err := findByNameOrID(collections, opts.Collection)
+		if err != nil {
+			return fmt.Errorf("unable to find collection '%s': %w", opts.Collection, err)
+		}
+		filters = append(filters, bw.WithCollectionID(collection.ID))
+	}
+
+	items, err := client.ListObjects(fmt.Sprintf("%ss", bw.ObjectTypeItem), filters...)
+	if err != nil {
+		return fmt.Errorf("error listing items: %w", err)
+	}
+
+	g := newGenerator()
+	g.generate(folders, collections, items)
+
+	_, err = w.Write(hclwrite.Format(g.file.Bytes()))
+	return err
+}
+
+type generator struct {
+	file  *hclwrite.File
+	names map[string]map[string]bool
+
+	// Resource addresses of already generated folders and collections,
+	// indexed by object ID.
+	folderAddresses     map[string]hcl.Traversal
+	collectionAddresses map[string]hcl.Traversal
+}
+
+func newGenerator() *generator {
+	return &generator{
+		file:                hclwrite.NewEmptyFile(),
+		names:               map[string]map[string]bool{},
+		folderAddresses:     map[string]hcl.Traversal{},
+		collectionAddresses: map[string]hcl.Traversal{},
+	}
+}
+
+func (g *generator) generate(folders, collections, items []bw.Object) {
+	for _, folder := range folders {
+		if len(folder.ID) > 0 && isReferenced(items, func(item bw.Object) bool { return item.FolderID == folder.ID }) {
+			g.generateFolder(folder)
+		}
+	}
+
+	for _, collection := range collections {
+		if isReferenced(items, func(item bw.Object) bool { return slices.Contains(item.CollectionIds, collection.ID) }) {
+			g.generateCollection(collection)
+		}
+	}
+
+	for _, item := range items {
+		if item.DeletedDate != nil {
+			continue
+		}
+
+		switch item.Type {
+		case bw.ItemTypeLogin:
+			g.generateItem(resourceItemLogin, item)
+		case bw.ItemTypeSecureNote:
+			g.generateItem(resourceItemSecureNote, item)
+		default:
+			g.file.Body().AppendUnstructuredTokens(comment(fmt.Sprintf("# Skipped item '%s' (%s): unsupported item type %d", item.Name, item.ID, item.Type)))
+			g.file.Body().AppendNewline()
+		}
+	}
+}
+
+func (g *generator) generateFolder(folder bw.Object) {
+	name := g.uniqueName(resourceFolder, folder.Name)
+
+	body := g.appendResource(resourceFolder, name)
+	body.SetAttributeValue("name", cty.StringVal(folder.Name))
+
+	g.appendImport(resourceFolder, name, folder.ID)
+	g.folderAddresses[folder.ID] = address(resourceFolder, name)
+}
+
+func (g *generator) generateCollection(collection bw.Object) {
+	name := g.uniqueName(resourceOrgCollection, collection.Name)
+
+	body := g.appendResource(resourceOrgCollection, name)
+	body.SetAttributeValue("name", cty.StringVal(collection.Name))
+	body.SetAttributeValue("organization_id", cty.StringVal(collection.OrganizationID))
+
+	g.appendImport(resourceOrgCollection, name, fmt.Sprintf("%s/%s", collection.OrganizationID, collection.ID))
+	g.collectionAddresses[collection.ID] = address(resourceOrgCollection, name)
+}
+
+func (g *generator) generateItem(resourceType string, item bw.Object) {
+	name := g.uniqueName(resourceType, item.Name)
+	secrets := []string{}
+	secretRef := func(suffix string) hcl.Traversal {
+		variable := g.uniqueName("variable", fmt.Sprintf("%s_%s", name, suffix))
+		secrets = append(secrets, variable)
+		return hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: variable}}
+	}
+
+	body := g.appendResource(resourceType, name)
+	body.SetAttributeValue("name", cty.StringVal(item.Name))
+
+	if len(item.FolderID) > 0 {
+		if ref, ok := g.folderAddresses[item.FolderID]; ok {
+			body.SetAttributeTraversal("folder_id", append(ref, hcl.TraverseAttr{Name: "id"}))
+		} else {
+			body.SetAttributeValue("folder_id", cty.StringVal(item.FolderID))
+		}
+	}
+
+	if len(item.OrganizationID) > 0 {
+		body.SetAttributeValue("organization_id", cty.StringVal(item.OrganizationID))
+	}
+
+	if len(item.CollectionIds) > 0 {
+		elems := make([]hclwrite.Tokens, len(item.CollectionIds))
+		for k, id := range item.CollectionIds {
+			if ref, ok := g.collectionAddresses[id]; ok {
+				elems[k] = hclwrite.TokensForTraversal(append(ref, hcl.TraverseAttr{Name: "id"}))
+			} else {
+				elems[k] = hclwrite.TokensForValue(cty.StringVal(id))
+			}
+		}
+		body.SetAttributeRaw("collection_ids", hclwrite.TokensForTuple(elems))
+	}
+
+	if item.Favorite {
+		body.SetAttributeValue("favorite", cty.True)
+	}
+
+	if item.Reprompt == 1 {
+		body.SetAttributeValue("reprompt", cty.True)
+	}
+
+	if len(item.Notes) > 0 {
+		body.SetAttributeTraversal("notes", secretRef("notes"))
+	}
+
+	if item.Type == bw.ItemTypeLogin {
+		if len(item.Login.Username) > 0 {
+			body.SetAttributeValue("username", cty.StringVal(item.Login.Username))
+		}
+		if len(item.Login.Password) > 0 {
+			body.SetAttributeTraversal("password", secretRef("password"))
+		}
+		if len(item.Login.Totp) > 0 {
+			body.SetAttributeTraversal("totp", secretRef("totp"))
+		}
+		for _, uri := range item.Login.URIs {
+			uriBody := body.AppendNewBlock("uri", nil).Body()
+			if uri.Match != nil {
+				name, _ := bw.URIMatchName(uri.Match)
+				uriBody.SetAttributeValue("match", cty.StringVal(name))
+			}
+			uriBody.SetAttributeValue("value", cty.StringVal(uri.URI))
+		}
+	}
+
+	for _, field := range item.Fields {
+		fieldBody := body.AppendNewBlock("field", nil).Body()
+		fieldBody.SetAttributeValue("name", cty.StringVal(field.Name))
+		switch field.Type {
+		case bw.FieldTypeText:
+			fieldBody.SetAttributeValue("text", cty.StringVal(field.Value))
+		case bw.FieldTypeBoolean:
+			fieldBody.SetAttributeValue("boolean", cty.BoolVal(field.Value == "true"))
+		case bw.FieldTypeHidden:
+			fieldBody.SetAttributeTraversal("hidden", secretRef(fmt.Sprintf("field_%s", field.Name)))
+		case bw.FieldTypeLinked:
+			fieldBody.SetAttributeValue("linked", cty.StringVal(field.Value))
+		}
+	}
+
+	g.appendImport(resourceType, name, item.ID)
+
+	for _, variable := range secrets {
+		variableBody := g.file.Body().AppendNewBlock("variable", []string{variable}).Body()
+		variableBody.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		variableBody.SetAttributeValue("sensitive", cty.True)
+		g.file.Body().AppendNewline()
+	}
+}
+
+func (g *generator) appendResource(resourceType, name string) *hclwrite.Body {
+	block := g.file.Body().AppendNewBlock("resource", []string{resourceType, name})
+	g.file.Body().AppendNewline()
+	return block.Body()
+}
+
+func (g *generator) appendImport(resourceType, name, id string) {
+	body := g.file.Body().AppendNewBlock("import", nil).Body()
+	body.SetAttributeTraversal("to", address(resourceType, name))
+	body.SetAttributeValue("id", cty.StringVal(id))
+	g.file.Body().AppendNewline()
+}
+
+// uniqueName returns a valid Terraform identifier derived from the object's
+// name, which isn't used yet by another resource of the same type.
+func (g *generator) uniqueName(resourceType, objName string) string {
+	if _, ok := g.names[resourceType]; !ok {
+		g.names[resourceType] = map[string]bool{}
+	}
+
+	base := identifier(objName)
+	name := base
+	for i := 2; g.names[resourceType][name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.names[resourceType][name] = true
+	return name
+}
+
+func identifier(s string) string {
+	id := strings.Trim(invalidIdentifierChars.ReplaceAllString(strings.ToLower(s), "_"), "_")
+	if len(id) == 0 {
+		return "unnamed"
+	}
+	if id[0] >= '0' && id[0] <= '9' || id[0] == '-' {
+		id = "_" + id
+	}
+	return id
+}
+
+func address(resourceType, name string) hcl.Traversal {
+	return hcl.Traversal{hcl.TraverseRoot{Name: resourceType}, hcl.TraverseAttr{Name: name}}
+}
+
+func comment(text string) hclwrite.Tokens {
+	return hclwrite.Tokens{{Type: hclsyntax.TokenComment, Bytes: []byte(text + "\n")}}
+}
+
+func findByNameOrID(objs []bw.Object, nameOrID string) (*bw.Object, error) {
+	var match *bw.Object
+	for k, obj := range objs {
+		if obj.ID == nameOrID {
+			return &objs[k], nil
+		}
+		if obj.Name == nameOrID {
+			if match != nil {
+				return nil, fmt.Errorf("more than one object named '%s', use its identifier instead", nameOrID)
+			}
+			match = &objs[k]
+		}
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("no object found")
+	}
+	return match, nil
+}
+
+func isReferenced(items []bw.Object, fn func(bw.Object) bool) bool {
+	for _, item := range items {
+		if item.DeletedDate == nil && fn(item) {
+			return true
+		}
+	}
+	return false
+}
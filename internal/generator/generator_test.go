@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"list folders":     `[{"id": "folder-1", "name": "Databases"}, {"id": "folder-2", "name": "Unused"}]`,
+		"list collections": `[{"id": "coll-1", "organizationId": "org-1", "name": "Engineering/Backend"}]`,
+		"list items --folderid folder-1": `[
+			{"id": "item-1", "type": 1, "name": "Postgres Admin", "folderId": "folder-1", "organizationId": "org-1", "collectionIds": ["coll-1"], "login": {"username": "admin", "password": "secret", "uris": [{"match": 1, "uri": "https://db.example.com"}]}, "fields": [{"name": "port", "value": "5432", "type": 0}, {"name": "token", "value": "hidden-value", "type": 1}]},
+			{"id": "item-2", "type": 2, "name": "Postgres Admin", "folderId": "folder-1", "notes": "runbook", "reprompt": 1},
+			{"id": "item-3", "type": 3, "name": "Card", "folderId": "folder-1"}
+		]`,
+	})
+	defer removeMocks(t)
+
+	var out bytes.Buffer
+	err := Generate(&out, bw.NewClient("dummy"), Options{Folder: "Databases"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `resource "bitwarden_folder" "databases" {
+  name = "Databases"
+}
+
+import {
+  to = bitwarden_folder.databases
+  id = "folder-1"
+}
+
+resource "bitwarden_org_collection" "engineering_backend" {
+  name            = "Engineering/Backend"
+  organization_id = "org-1"
+}
+
+import {
+  to = bitwarden_org_collection.engineering_backend
+  id = "org-1/coll-1"
+}
+
+resource "bitwarden_item_login" "postgres_admin" {
+  name            = "Postgres Admin"
+  folder_id       = bitwarden_folder.databases.id
+  organization_id = "org-1"
+  collection_ids  = [bitwarden_org_collection.engineering_backend.id]
+  username        = "admin"
+  password        = var.postgres_admin_password
+  uri {
+    match = "host"
+    value = "https://db.example.com"
+  }
+  field {
+    name = "port"
+    text = "5432"
+  }
+  field {
+    name   = "token"
+    hidden = var.postgres_admin_field_token
+  }
+}
+
+import {
+  to = bitwarden_item_login.postgres_admin
+  id = "item-1"
+}
+
+variable "postgres_admin_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "postgres_admin_field_token" {
+  type      = string
+  sensitive = true
+}
+
+resource "bitwarden_item_secure_note" "postgres_admin" {
+  name      = "Postgres Admin"
+  folder_id = bitwarden_folder.databases.id
+  reprompt  = true
+  notes     = var.postgres_admin_notes
+}
+
+import {
+  to = bitwarden_item_secure_note.postgres_admin
+  id = "item-2"
+}
+
+variable "postgres_admin_notes" {
+  type      = string
+  sensitive = true
+}
+
+# Skipped item 'Card' (item-3): unsupported item type 3
+
+`, out.String())
+}
+
+func TestIdentifier(t *testing.T) {
+	assert.Equal(t, "vpn_credentials", identifier("VPN/Credentials"))
+	assert.Equal(t, "_1password_import", identifier("1Password Import"))
+	assert.Equal(t, "unnamed", identifier("***"))
+}
+
+func TestRunUsesBWExecutable(t *testing.T) {
+	t.Setenv("BW_SESSION", "session-key")
+	executable := filepath.Join(t.TempDir(), "custom-bw")
+
+	t.Setenv("BW_EXECUTABLE", executable)
+	err := Run(context.Background(), []string{}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, executable)
+
+	other := filepath.Join(t.TempDir(), "other-bw")
+	err = Run(context.Background(), []string{"--bw-executable", other}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, other)
+}
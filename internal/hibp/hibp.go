@@ -0,0 +1,100 @@
+// Package hibp looks passwords up in a local copy of the Pwned Passwords of
+// Have I Been Pwned, so that neither passwords nor their hashes leave the
+// machine.
+package hibp
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// prefixLength is the length of the hash prefixes ranges are keyed by.
+const prefixLength = 5
+
+// Hash returns the SHA-1 of a password the way Pwned Passwords lists it,
+// i.e. in uppercase hexadecimal.
+func Hash(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// Exposed returns which of the given hashes appear in the Pwned Passwords
+// at path, which is either:
+//   - a file of '<SHA-1>:<count>' lines, as downloaded in a single file,
+//   - a directory of range files named '<first 5 characters of the SHA-1>.txt'
+//     holding '<other 35 characters>:<count>' lines, as returned by the range
+//     API.
+//
+// Entries with a count of 0, which the range API adds as padding, are ignored.
+func Exposed(path string, hashes []string) (map[string]bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Pwned Passwords: %w", err)
+	}
+
+	wanted := map[string]bool{}
+	for _, hash := range hashes {
+		wanted[strings.ToUpper(hash)] = true
+	}
+
+	exposed := map[string]bool{}
+	if !info.IsDir() {
+		err = scan(path, func(hash string) {
+			if wanted[hash] {
+				exposed[hash] = true
+			}
+		})
+		return exposed, err
+	}
+
+	ranges := map[string]bool{}
+	for hash := range wanted {
+		ranges[hash[:min(prefixLength, len(hash))]] = true
+	}
+	for prefix := range ranges {
+		err = scan(filepath.Join(path, prefix+".txt"), func(suffix string) {
+			if wanted[prefix+suffix] {
+				exposed[prefix+suffix] = true
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return exposed, nil
+}
+
+// scan calls found with the hash, or hash suffix, of every line of a Pwned
+// Passwords file.
+func scan(path string, found func(hash string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to read Pwned Passwords: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 {
+			continue
+		}
+
+		hash, count, ok := strings.Cut(text, ":")
+		if !ok {
+			return fmt.Errorf("malformed Pwned Passwords line %d of '%s': expected '<hash>:<count>'", line, path)
+		}
+		if strings.TrimSpace(count) != "0" {
+			found(strings.ToUpper(hash))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read Pwned Passwords: %w", err)
+	}
+	return nil
+}
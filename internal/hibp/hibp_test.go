@@ -0,0 +1,47 @@
+package hibp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	passwordHash = "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8"
+	qwertyHash   = "B1B3773A05C0ED0176787A4F1574FF0075F7521E"
+)
+
+func TestHash(t *testing.T) {
+	assert.Equal(t, passwordHash, Hash("password"))
+}
+
+func TestExposedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pwnedpasswords.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("000000005AD76BD555C1D6D771DE417A4B87E4B4:10\r\n"+passwordHash+":9659365\r\n"+qwertyHash+":0\r\n"), 0600))
+
+	exposed, err := Exposed(path, []string{passwordHash, qwertyHash, Hash("x7#Kp!2mQz9v")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{passwordHash: true}, exposed)
+}
+
+func TestExposedRangeDirectory(t *testing.T) {
+	path := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(path, "5BAA6.txt"), []byte("003D68EB55068C33ACE09247EE4C639306B:3\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365\n"), 0600))
+
+	exposed, err := Exposed(path, []string{passwordHash})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{passwordHash: true}, exposed)
+
+	_, err = Exposed(path, []string{qwertyHash})
+	assert.ErrorContains(t, err, "B1B37.txt")
+}
+
+func TestExposedMalformedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pwnedpasswords.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(passwordHash+"\n"), 0600))
+
+	_, err := Exposed(path, []string{passwordHash})
+	assert.ErrorContains(t, err, "malformed Pwned Passwords line 1")
+}
@@ -0,0 +1,516 @@
+// Package passwordstrength estimates the strength of passwords the way zxcvbn
+// does, which is what Bitwarden clients rely on for their weak passwords
+// report: a password is split into the patterns an attacker would try first
+// (common passwords, keyboard rows, sequences, repeats and years) and scored
+// from the number of guesses needed to find it.
+package passwordstrength
+
+import (
+	_ "embed"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	// maxLength bounds the part of a password which is analyzed, as longer
+	// passwords are strong unless their beginning is a pattern.
+	maxLength = 100
+
+	bruteforceCardinality           = 10
+	minGuessesSingleChar            = 10
+	minGuessesMultiChar             = 50
+	minGuessesBeforeGrowingSequence = 10000
+	minYearSpace                    = 20
+	keyboardStartingPositions       = 94
+	keyboardAverageDegree           = 4.6
+)
+
+// commonPasswordsTxt lists common passwords, most common first.
+//
+//go:embed common_passwords.txt
+var commonPasswordsTxt string
+
+var commonPasswords = sync.OnceValue(func() map[string]int {
+	return rankedDictionary(strings.Fields(commonPasswordsTxt))
+})
+
+// referenceYear is the year recent years are guessed from.
+var referenceYear = time.Now().Year()
+
+var keyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+	"azertyuiop",
+	"qsdfghjklm",
+	"wxcvbn",
+	"qwertzuiop",
+	"yxcvbnm",
+	"789456123",
+}
+
+var l33tTable = map[rune][]rune{
+	'4': {'a'},
+	'@': {'a'},
+	'8': {'b'},
+	'(': {'c'},
+	'{': {'c'},
+	'[': {'c'},
+	'<': {'c'},
+	'3': {'e'},
+	'6': {'g'},
+	'9': {'g'},
+	'1': {'i', 'l'},
+	'!': {'i'},
+	'|': {'i', 'l'},
+	'7': {'l', 't'},
+	'0': {'o'},
+	'$': {'s'},
+	'5': {'s'},
+	'+': {'t'},
+	'%': {'x'},
+	'2': {'z'},
+}
+
+// maxL33tSubstitutions bounds the number of ways l33t characters of a
+// password are translated back to letters.
+const maxL33tSubstitutions = 64
+
+// match is a pattern found in runes i to j of a password.
+type match struct {
+	i, j    int
+	guesses float64
+}
+
+// Score returns the strength of a password, from 0 (too guessable) to 4 (very
+// unguessable). userInputs are strings the password shouldn't be based on,
+// e.g. the username of the login.
+func Score(password string, userInputs ...string) int {
+	guesses := Guesses(password, userInputs...)
+	switch {
+	case guesses < 1e3+5:
+		return 0
+	case guesses < 1e6+5:
+		return 1
+	case guesses < 1e8+5:
+		return 2
+	case guesses < 1e10+5:
+		return 3
+	}
+	return 4
+}
+
+// Guesses estimates the number of guesses needed to find a password.
+func Guesses(password string, userInputs ...string) float64 {
+	inputs := make([]string, 0, len(userInputs))
+	for _, input := range userInputs {
+		if input = strings.ToLower(strings.TrimSpace(input)); len(input) > 0 {
+			inputs = append(inputs, input)
+		}
+	}
+
+	runes := []rune(password)
+	if len(runes) > maxLength {
+		runes = runes[:maxLength]
+	}
+	return guesses(runes, []map[string]int{rankedDictionary(inputs), commonPasswords()})
+}
+
+func guesses(runes []rune, dictionaries []map[string]int) float64 {
+	if len(runes) == 0 {
+		return 1
+	}
+
+	var matches []match
+	matches = append(matches, dictionaryMatches(runes, dictionaries)...)
+	matches = append(matches, l33tMatches(runes, dictionaries)...)
+	matches = append(matches, spatialMatches(runes)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, repeatMatches(runes, dictionaries)...)
+	matches = append(matches, yearMatches(runes)...)
+	return mostGuessableSequence(len(runes), matches)
+}
+
+// mostGuessableSequence finds the sequence of non-overlapping matches
+// covering the password which needs the fewest guesses, characters not
+// covered by any pattern being bruteforced, and returns its guesses.
+func mostGuessableSequence(n int, matches []match) float64 {
+	byEnd := make([][]match, n)
+	for _, m := range matches {
+		if m.j-m.i+1 < n {
+			m.guesses = math.Max(m.guesses, minSubmatchGuesses(m.j-m.i+1))
+		}
+		byEnd[m.j] = append(byEnd[m.j], m)
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			length := j - i + 1
+			byEnd[j] = append(byEnd[j], match{i: i, j: j, guesses: math.Max(math.Pow(bruteforceCardinality, float64(length)), minSubmatchGuesses(length)+1)})
+		}
+	}
+
+	// products[k][l] is the lowest product of the guesses of l matches
+	// covering the first k+1 characters.
+	products := make([][]float64, n)
+	for k := range products {
+		products[k] = make([]float64, n+1)
+		for l := range products[k] {
+			products[k][l] = math.Inf(1)
+		}
+	}
+	for k := 0; k < n; k++ {
+		for _, m := range byEnd[k] {
+			if m.i == 0 {
+				products[k][1] = math.Min(products[k][1], m.guesses)
+				continue
+			}
+			for l, product := range products[m.i-1][:n] {
+				products[k][l+1] = math.Min(products[k][l+1], product*m.guesses)
+			}
+		}
+	}
+
+	best := math.Inf(1)
+	factorial := 1.0
+	for l := 1; l <= n; l++ {
+		factorial *= float64(l)
+		best = math.Min(best, factorial*products[n-1][l]+math.Pow(minGuessesBeforeGrowingSequence, float64(l-1)))
+	}
+	return best
+}
+
+func minSubmatchGuesses(length int) float64 {
+	if length == 1 {
+		return minGuessesSingleChar
+	}
+	return minGuessesMultiChar
+}
+
+func dictionaryMatches(runes []rune, dictionaries []map[string]int) []match {
+	lower := toLower(runes)
+
+	var matches []match
+	for i := range lower {
+		for j := i; j < len(lower); j++ {
+			token := string(lower[i : j+1])
+			if rank, ok := lookup(dictionaries, token); ok {
+				matches = append(matches, match{i: i, j: j, guesses: float64(rank) * uppercaseVariations(runes[i:j+1])})
+			}
+			if reversed := reverse(token); j > i && reversed != token {
+				if rank, ok := lookup(dictionaries, reversed); ok {
+					matches = append(matches, match{i: i, j: j, guesses: float64(rank) * uppercaseVariations(runes[i:j+1]) * 2})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// l33tMatches finds common passwords written with l33t characters, e.g.
+// 'p4ssw0rd'.
+func l33tMatches(runes []rune, dictionaries []map[string]int) []match {
+	lower := toLower(runes)
+
+	var matches []match
+	for _, substitution := range l33tSubstitutions(lower) {
+		translated := make([]rune, len(lower))
+		for k, r := range lower {
+			translated[k] = r
+			if letter, ok := substitution[r]; ok {
+				translated[k] = letter
+			}
+		}
+
+		for i := range translated {
+			for j := i; j < len(translated); j++ {
+				token := lower[i : j+1]
+				if !containsAny(token, substitution) {
+					continue
+				}
+				if rank, ok := lookup(dictionaries, string(translated[i:j+1])); ok {
+					guesses := float64(rank) * uppercaseVariations(runes[i:j+1]) * l33tVariations(token, substitution)
+					matches = append(matches, match{i: i, j: j, guesses: guesses})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// l33tSubstitutions returns the ways l33t characters of a password can be
+// translated back to letters.
+func l33tSubstitutions(runes []rune) []map[rune]rune {
+	substitutions := []map[rune]rune{{}}
+	seen := map[rune]bool{}
+	for _, r := range runes {
+		letters, ok := l33tTable[r]
+		if !ok || seen[r] {
+			continue
+		}
+		seen[r] = true
+
+		var next []map[rune]rune
+		for _, substitution := range substitutions {
+			for _, letter := range letters {
+				if len(next) == maxL33tSubstitutions {
+					break
+				}
+				extended := map[rune]rune{r: letter}
+				for k, v := range substitution {
+					extended[k] = v
+				}
+				next = append(next, extended)
+			}
+		}
+		substitutions = next
+	}
+
+	if len(seen) == 0 {
+		return nil
+	}
+	return substitutions
+}
+
+func l33tVariations(token []rune, substitution map[rune]rune) float64 {
+	variations := 1.0
+	for l33t, letter := range substitution {
+		substituted, unsubstituted := 0, 0
+		for _, r := range token {
+			switch r {
+			case l33t:
+				substituted++
+			case letter:
+				unsubstituted++
+			}
+		}
+		if substituted == 0 {
+			continue
+		}
+		variations *= casesVariations(substituted, unsubstituted)
+	}
+	return variations
+}
+
+// uppercaseVariations returns the number of ways a word could be
+// capitalized, common capitalizations being counted as a single one.
+func uppercaseVariations(token []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range token {
+		if unicode.IsUpper(r) {
+			upper++
+		} else if unicode.IsLower(r) {
+			lower++
+		}
+	}
+
+	if upper == 0 {
+		return 1
+	}
+	if lower == 0 || (upper == 1 && (unicode.IsUpper(token[0]) || unicode.IsUpper(token[len(token)-1]))) {
+		return 2
+	}
+	return casesVariations(upper, lower)
+}
+
+// casesVariations returns the number of ways to pick at most min(a, b) of
+// a+b characters, or 2 when either a or b is 0.
+func casesVariations(a, b int) float64 {
+	if a == 0 || b == 0 {
+		return 2
+	}
+
+	variations := 0.0
+	for k := 1; k <= min(a, b); k++ {
+		variations += binomial(a+b, k)
+	}
+	return variations
+}
+
+// spatialMatches finds runs of adjacent keys of a keyboard row, e.g. 'qwerty'
+// or 'lkjh'.
+func spatialMatches(runes []rune) []match {
+	lower := toLower(runes)
+
+	var matches []match
+	for _, row := range keyboardRows {
+		columns := map[rune]int{}
+		for k, r := range row {
+			columns[r] = k
+		}
+
+		for i := 0; i < len(lower); {
+			j, turns, direction := i, 0, 0
+			for j+1 < len(lower) {
+				from, ok := columns[lower[j]]
+				to, ok2 := columns[lower[j+1]]
+				if !ok || !ok2 || (to-from != 1 && to-from != -1) {
+					break
+				}
+				if to-from != direction {
+					turns++
+					direction = to - from
+				}
+				j++
+			}
+
+			if length := j - i + 1; length >= 3 {
+				matches = append(matches, match{i: i, j: j, guesses: spatialGuesses(runes[i:j+1], turns)})
+				i = j
+				continue
+			}
+			i++
+		}
+	}
+	return matches
+}
+
+func spatialGuesses(token []rune, turns int) float64 {
+	guesses := 0.0
+	for i := 2; i <= len(token); i++ {
+		for j := 1; j <= min(turns, i-1); j++ {
+			guesses += binomial(i-1, j-1) * keyboardStartingPositions * math.Pow(keyboardAverageDegree, float64(j))
+		}
+	}
+
+	shifted := 0
+	for _, r := range token {
+		if unicode.IsUpper(r) {
+			shifted++
+		}
+	}
+	if shifted > 0 {
+		guesses *= casesVariations(shifted, len(token)-shifted)
+	}
+	return guesses
+}
+
+// sequenceMatches finds runs of characters separated by the same small
+// interval, e.g. 'abcd', '9753' or 'acegi'.
+func sequenceMatches(runes []rune) []match {
+	var matches []match
+	for i := 0; i+1 < len(runes); {
+		delta := runes[i+1] - runes[i]
+		j := i + 1
+		for j+1 < len(runes) && runes[j+1]-runes[j] == delta {
+			j++
+		}
+
+		if length := j - i + 1; length >= 3 && delta != 0 && delta >= -5 && delta <= 5 {
+			base := 26.0
+			if strings.ContainsRune("aAzZ019", runes[i]) {
+				base = 4
+			} else if unicode.IsDigit(runes[i]) {
+				base = 10
+			}
+			if delta < 0 {
+				base *= 2
+			}
+			matches = append(matches, match{i: i, j: j, guesses: base * float64(length)})
+		}
+		i = j
+	}
+	return matches
+}
+
+// repeatMatches finds repeated strings, e.g. 'aaaa' or 'abcabc'.
+func repeatMatches(runes []rune, dictionaries []map[string]int) []match {
+	var matches []match
+	for i := 0; i < len(runes); {
+		bestLength, bestRepeats := 0, 0
+		for length := 1; i+2*length <= len(runes); length++ {
+			repeats := 1
+			for i+(repeats+1)*length <= len(runes) && string(runes[i+repeats*length:i+(repeats+1)*length]) == string(runes[i:i+length]) {
+				repeats++
+			}
+			if repeats >= 2 && repeats*length > bestRepeats*bestLength {
+				bestLength, bestRepeats = length, repeats
+			}
+		}
+
+		if bestRepeats == 0 {
+			i++
+			continue
+		}
+		baseGuesses := guesses(runes[i:i+bestLength], dictionaries)
+		matches = append(matches, match{i: i, j: i + bestLength*bestRepeats - 1, guesses: baseGuesses * float64(bestRepeats)})
+		i += bestLength * bestRepeats
+	}
+	return matches
+}
+
+// yearMatches finds years between 1900 and 2099.
+func yearMatches(runes []rune) []match {
+	var matches []match
+	for i := 0; i+4 <= len(runes); i++ {
+		token := string(runes[i : i+4])
+		if !strings.HasPrefix(token, "19") && !strings.HasPrefix(token, "20") {
+			continue
+		}
+		year, err := strconv.Atoi(token)
+		if err != nil {
+			continue
+		}
+		matches = append(matches, match{i: i, j: i + 3, guesses: math.Max(math.Abs(float64(year-referenceYear)), minYearSpace)})
+	}
+	return matches
+}
+
+func rankedDictionary(words []string) map[string]int {
+	dictionary := make(map[string]int, len(words))
+	for k, word := range words {
+		if _, ok := dictionary[word]; !ok {
+			dictionary[word] = k + 1
+		}
+	}
+	return dictionary
+}
+
+func lookup(dictionaries []map[string]int, token string) (int, bool) {
+	for _, dictionary := range dictionaries {
+		if rank, ok := dictionary[token]; ok {
+			return rank, true
+		}
+	}
+	return 0, false
+}
+
+func containsAny(token []rune, substitution map[rune]rune) bool {
+	for _, r := range token {
+		if _, ok := substitution[r]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func toLower(runes []rune) []rune {
+	lower := make([]rune, len(runes))
+	for k, r := range runes {
+		lower[k] = unicode.ToLower(r)
+	}
+	return lower
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
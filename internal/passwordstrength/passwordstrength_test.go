@@ -0,0 +1,57 @@
+package passwordstrength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	testCases := map[string]int{
+		"":                          0,
+		"password":                  0,
+		"drowssap":                  0,
+		"qwerty123":                 0,
+		"aaaaaaaa":                  0,
+		"abcabcabcabc":              0,
+		"abcdefgh":                  0,
+		"1990":                      0,
+		"P4ssw0rd!":                 1,
+		"asdfghjkl;":                1,
+		"Summer2019!":               2,
+		"x7#Kp!2mQz9v":              4,
+		"correcthorsebatterystaple": 4,
+	}
+
+	for password, expected := range testCases {
+		t.Run(password, func(t *testing.T) {
+			assert.Equal(t, expected, Score(password))
+		})
+	}
+}
+
+func TestScoreWithUserInputs(t *testing.T) {
+	assert.Equal(t, 4, Score("jdoe-laverse"))
+	assert.Equal(t, 2, Score("jdoe-laverse", "JDoe", "laverse"))
+}
+
+func TestGuessesLongPasswords(t *testing.T) {
+	long := ""
+	for len(long) < 10*maxLength {
+		long += "x7#Kp!2mQz9v"
+	}
+	assert.Equal(t, 4, Score(long))
+}
+
+func TestUppercaseVariations(t *testing.T) {
+	assert.Equal(t, 1.0, uppercaseVariations([]rune("password")))
+	assert.Equal(t, 2.0, uppercaseVariations([]rune("Password")))
+	assert.Equal(t, 2.0, uppercaseVariations([]rune("passworD")))
+	assert.Equal(t, 2.0, uppercaseVariations([]rune("PASSWORD")))
+	assert.Equal(t, 8.0, uppercaseVariations([]rune("paSsword")))
+}
+
+func TestL33tSubstitutions(t *testing.T) {
+	assert.Nil(t, l33tSubstitutions([]rune("password")))
+	assert.ElementsMatch(t, []map[rune]rune{{'4': 'a', '1': 'i'}, {'4': 'a', '1': 'l'}}, l33tSubstitutions([]rune("4pp1e")))
+}
@@ -18,25 +18,25 @@ func attachmentCreate(ctx context.Context, d *schema.ResourceData, meta interfac
 
 	existingAttachments, err := listExistingAttachments(meta.(bw.Client), itemId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	filePath := d.Get(attributeAttachmentFile).(string)
 	obj, err := meta.(bw.Client).CreateAttachment(itemId, filePath)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	attachmentsRemoved, attachmentsAdded := compareLists(existingAttachments, obj.Attachments)
 	if len(attachmentsAdded) == 0 {
-		return diag.FromErr(errors.New("BUG: no attachment found after creation"))
+		return diagFromErr(errors.New("BUG: no attachment found after creation"))
 	} else if len(attachmentsAdded) > 1 {
-		return diag.FromErr(errors.New("BUG: more than one attachment created"))
+		return diagFromErr(errors.New("BUG: more than one attachment created"))
 	} else if len(attachmentsRemoved) > 1 {
-		return diag.FromErr(errors.New("BUG: at least one attachment removed"))
+		return diagFromErr(errors.New("BUG: at least one attachment removed"))
 	}
 
-	return diag.FromErr(attachmentDataFromStruct(d, attachmentsAdded[0]))
+	return diagFromErr(attachmentDataFromStruct(d, attachmentsAdded[0]))
 }
 
 func attachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -48,12 +48,12 @@ func attachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{
 		// deleted, because we won't have an item to attach it to.
 		// This means we don't need a special handling for NotFound errors and
 		// should just return whatever we get.
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	for _, attachment := range obj.Attachments {
 		if attachment.ID == d.Id() {
-			return diag.FromErr(attachmentDataFromStruct(d, attachment))
+			return diagFromErr(attachmentDataFromStruct(d, attachment))
 		}
 	}
 
@@ -65,7 +65,7 @@ func attachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{
 
 func attachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	itemId := d.Get(attributeAttachmentItemID).(string)
-	return diag.FromErr(meta.(bw.Client).DeleteAttachment(itemId, d.Id()))
+	return diagFromErr(meta.(bw.Client).DeleteAttachment(itemId, d.Id()))
 }
 
 func attachmentDataFromStruct(d *schema.ResourceData, attachment bw.Attachment) error {
@@ -100,12 +100,12 @@ func readDataSourceAttachment() schema.ReadContextFunc {
 
 		content, err := meta.(bw.Client).GetAttachment(itemId, attachmentId)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		d.SetId(attachmentId)
 
-		return diag.FromErr(d.Set(attributeAttachmentContent, string(content)))
+		return diagFromErr(d.Set(attributeAttachmentContent, string(content)))
 	}
 }
 
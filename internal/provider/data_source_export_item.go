@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/encryptedexport"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceExportItem() *schema.Resource {
+	dataSourceExportItemSchema := baseSchema(DataSource)
+	for k, v := range loginSchema(DataSource) {
+		dataSourceExportItemSchema[k] = v
+	}
+
+	dataSourceExportItemSchema[attributeExportFile] = &schema.Schema{
+		Description: descriptionExportFile,
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	dataSourceExportItemSchema[attributeExportPassword] = &schema.Schema{
+		Description: descriptionExportPassword,
+		Type:        schema.TypeString,
+		Required:    true,
+		Sensitive:   true,
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to get information on an item stored in a password-protected Bitwarden JSON export, without contacting the Bitwarden server.",
+		ReadContext: readDataSourceExportItem,
+		Schema:      dataSourceExportItemSchema,
+	}
+}
+
+func readDataSourceExportItem(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	exportClient, err := encryptedexport.NewClientFromFile(d.Get(attributeExportFile).(string), d.Get(attributeExportPassword).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get(attributeID).(string))
+	err = d.Set(attributeObject, bw.ObjectTypeItem)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(d.Id()) == 0 {
+		return diag.FromErr(objectSearch(d, exportClient))
+	}
+
+	obj, err := exportClient.GetObject(bw.Object{ID: d.Id(), Object: bw.ObjectTypeItem})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.FromErr(objectDataFromStruct(d, obj))
+}
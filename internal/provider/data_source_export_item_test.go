@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testExportFile     = "fixtures/export.json"
+	testExportPassword = "test1234"
+)
+
+func TestDataSourceExportItemBySearch(t *testing.T) {
+	d := dataSourceExportItem().TestResourceData()
+	assert.NoError(t, d.Set(attributeExportFile, testExportFile))
+	assert.NoError(t, d.Set(attributeExportPassword, testExportPassword))
+	assert.NoError(t, d.Set(attributeFilterSearch, "login-bar"))
+
+	diags := readDataSourceExportItem(context.Background(), d, nil)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	assert.Equal(t, "0f4b6c2e-8d1a-4c3b-9e5f-7a6d5c4b3a21", d.Id())
+	assert.Equal(t, "test-username", d.Get(attributeLoginUsername))
+	assert.Equal(t, "test-password", d.Get(attributeLoginPassword))
+	assert.Equal(t, "5e7b1ea4-4e9c-4e0f-9f8d-2c1f4c0b6a11", d.Get(attributeFolderID))
+	assert.Equal(t, "value-text", d.Get("field.0.text"))
+	assert.Equal(t, true, d.Get(attributeReprompt))
+}
+
+func TestDataSourceExportItemByID(t *testing.T) {
+	d := dataSourceExportItem().TestResourceData()
+	assert.NoError(t, d.Set(attributeExportFile, testExportFile))
+	assert.NoError(t, d.Set(attributeExportPassword, testExportPassword))
+	assert.NoError(t, d.Set(attributeID, "8a9b0c1d-2e3f-4a5b-8c7d-9e0f1a2b3c4d"))
+
+	diags := readDataSourceExportItem(context.Background(), d, nil)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	assert.Equal(t, "note-bar", d.Get(attributeName))
+	assert.Equal(t, 2, d.Get(attributeType))
+}
+
+func TestDataSourceExportItemFailsOnWrongPassword(t *testing.T) {
+	d := dataSourceExportItem().TestResourceData()
+	assert.NoError(t, d.Set(attributeExportFile, testExportFile))
+	assert.NoError(t, d.Set(attributeExportPassword, "wrong-password"))
+	assert.NoError(t, d.Set(attributeFilterSearch, "login-bar"))
+
+	diags := readDataSourceExportItem(context.Background(), d, nil)
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "invalid export password", diags[0].Summary)
+	}
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/urimatch"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceItemsForURL() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to find the login items Bitwarden clients would autofill on a URL, according to the URI match detection of their URIs and the global equivalent domains of Bitwarden. URIs with `regexp` match detection are evaluated with the RE2 syntax of Go: patterns using JavaScript features it lacks, like lookarounds or backreferences, never match.",
+		ReadContext: readDataSourceItemsForURL,
+		Schema: map[string]*schema.Schema{
+			attributeItemsForURL: {
+				Description: descriptionItemsForURL,
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			attributeItemsForURLDefaultMatch: {
+				Description:      descriptionItemsForURLDefaultMatch,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          string(URIMatchBaseDomain),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validURIMatchStr[1:], false)),
+			},
+			attributeFilterCollectionId: {
+				Description: descriptionFilterCollectionID,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeFilterFolderID: {
+				Description: descriptionFilterFolderID,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeFilterOrganizationID: {
+				Description: descriptionFilterOrganizationID,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeItemsForURLItems: {
+				Description: descriptionItemsForURLItems,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						attributeID: {
+							Description: descriptionIdentifier,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeName: {
+							Description: descriptionName,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeFolderID: {
+							Description: descriptionFolderID,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrganizationID: {
+							Description: descriptionOrganizationID,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeItemsForURLURI: {
+							Description: descriptionItemsForURLURI,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeItemsForURLMatch: {
+							Description: descriptionItemsForURLMatch,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readDataSourceItemsForURL(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rawURL := d.Get(attributeItemsForURL).(string)
+	matcher := urimatch.New(*strMatchToInt(d.Get(attributeItemsForURLDefaultMatch).(string)))
+
+	objs, err := meta.(bw.Client).ListObjects(fmt.Sprintf("%ss", bw.ObjectTypeItem), listOptionsFromData(d)...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	items := make([]interface{}, 0)
+	for _, obj := range bw.FilterObjectsByType(objs, bw.ItemTypeLogin) {
+		if obj.DeletedDate != nil {
+			continue
+		}
+
+		// Like Bitwarden clients, invalid URIs are skipped without preventing the
+		// other URIs of the item from matching.
+		uri, err := matcher.MatchingURI(obj.Login, rawURL)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Ignoring invalid URIs of item '%s' (%s)", obj.Name, obj.ID),
+				Detail:   err.Error(),
+			})
+		}
+		if uri == nil {
+			continue
+		}
+
+		items = append(items, map[string]interface{}{
+			attributeID:               obj.ID,
+			attributeName:             obj.Name,
+			attributeFolderID:         obj.FolderID,
+			attributeOrganizationID:   obj.OrganizationID,
+			attributeItemsForURLURI:   uri.URI,
+			attributeItemsForURLMatch: string(intMatchToStr(uri.Match)),
+		})
+	}
+
+	d.SetId(rawURL)
+	return append(diags, diag.FromErr(d.Set(attributeItemsForURLItems, items))...)
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceItemsForURL(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"list items --folderid folder-1": `[
+			{"id": "item-1", "object": "item", "type": 1, "name": "Google", "folderId": "folder-1", "login": {"uris": [{"uri": "https://accounts.google.com"}]}},
+			{"id": "item-2", "object": "item", "type": 1, "name": "Example", "folderId": "folder-1", "login": {"uris": [{"uri": "https://(youtube.com", "match": 4}, {"uri": "https://youtube.com/"}]}},
+			{"id": "item-3", "object": "item", "type": 1, "name": "YouTube", "folderId": "folder-1", "login": {"uris": [{"uri": "https://youtube.com", "match": 5}, {"uri": "https://www.youtube.com/login", "match": 2}]}},
+			{"id": "item-4", "object": "item", "type": 1, "name": "Deleted", "folderId": "folder-1", "deletedDate": "2024-01-01T10:00:00.000Z", "login": {"uris": [{"uri": "https://youtube.com"}]}},
+			{"id": "item-5", "object": "item", "type": 2, "name": "Note", "folderId": "folder-1"}
+		]`,
+	})
+	defer removeMocks(t)
+
+	d := dataSourceItemsForURL().TestResourceData()
+	d.Set(attributeItemsForURL, "https://www.youtube.com/login?next=/")
+	d.Set(attributeItemsForURLDefaultMatch, "base_domain")
+	d.Set(attributeFilterFolderID, "folder-1")
+
+	diags := readDataSourceItemsForURL(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if assert.False(t, diags.HasError(), diags) && assert.Len(t, diags, 1) {
+		assert.Equal(t, "Ignoring invalid URIs of item 'Example' (item-2)", diags[0].Summary)
+	}
+
+	assert.Equal(t, 3, d.Get("items.#"))
+	assert.Equal(t, "item-1", d.Get("items.0.id"))
+	assert.Equal(t, "https://accounts.google.com", d.Get("items.0.uri"))
+	assert.Equal(t, "default", d.Get("items.0.match"))
+	assert.Equal(t, "item-2", d.Get("items.1.id"))
+	assert.Equal(t, "https://youtube.com/", d.Get("items.1.uri"))
+	assert.Equal(t, "item-3", d.Get("items.2.id"))
+	assert.Equal(t, "YouTube", d.Get("items.2.name"))
+	assert.Equal(t, "folder-1", d.Get("items.2.folder_id"))
+	assert.Equal(t, "https://www.youtube.com/login", d.Get("items.2.uri"))
+	assert.Equal(t, "start_with", d.Get("items.2.match"))
+}
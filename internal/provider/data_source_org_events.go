@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/orgapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceOrgEvents() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the event logs of an organization, through the Bitwarden Public API. Requires the `organization_api_key` block to be configured on the provider.",
+		ReadContext: readDataSourceOrgEvents,
+		Schema: map[string]*schema.Schema{
+			attributeOrgEventsStart: {
+				Description:      descriptionOrgEventsStart,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			attributeOrgEventsEnd: {
+				Description:      descriptionOrgEventsEnd,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			attributeOrgEventsActingUserID: {
+				Description: descriptionOrgEventsFilterActingUser,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeOrgEventsItemID: {
+				Description: descriptionOrgEventsFilterItemID,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeOrgEventsContinuationToken: {
+				Description: descriptionOrgEventsContinuationToken,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeOrgEventsMaxPages: {
+				Description:      descriptionOrgEventsMaxPages,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			attributeOrgEventsNextToken: {
+				Description: descriptionOrgEventsNextToken,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeOrgEvents: {
+				Description: descriptionOrgEvents,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						attributeOrgEventsType: {
+							Description: descriptionOrgEventsType,
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						attributeOrgEventsTypeName: {
+							Description: descriptionOrgEventsTypeName,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrgEventsDate: {
+							Description: descriptionOrgEventsDate,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrgEventsActingUserID: {
+							Description: descriptionOrgEventsActingUserID,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrgEventsDevice: {
+							Description: descriptionOrgEventsDevice,
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						attributeOrgEventsDeviceName: {
+							Description: descriptionOrgEventsDeviceName,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrgEventsIPAddress: {
+							Description: descriptionOrgEventsIPAddress,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrgEventsItemID: {
+							Description: descriptionOrgEventsItemID,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrgEventsCollectionID: {
+							Description: descriptionOrgEventsCollectionID,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrgEventsGroupID: {
+							Description: descriptionOrgEventsGroupID,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrgEventsMemberID: {
+							Description: descriptionOrgEventsMemberID,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeOrgEventsPolicyID: {
+							Description: descriptionOrgEventsPolicyID,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readDataSourceOrgEvents(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := orgAPIClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Both dates have been validated at plan time.
+	start, _ := time.Parse(time.RFC3339, d.Get(attributeOrgEventsStart).(string))
+	end, _ := time.Parse(time.RFC3339, d.Get(attributeOrgEventsEnd).(string))
+
+	filter := orgapi.EventsFilter{
+		Start:             start,
+		End:               end,
+		ActingUserID:      d.Get(attributeOrgEventsActingUserID).(string),
+		ItemID:            d.Get(attributeOrgEventsItemID).(string),
+		ContinuationToken: d.Get(attributeOrgEventsContinuationToken).(string),
+	}
+
+	events, nextToken, err := client.ListEvents(filter, d.Get(attributeOrgEventsMaxPages).(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	eventList := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		m := map[string]interface{}{
+			attributeOrgEventsType:         event.Type,
+			attributeOrgEventsTypeName:     orgapi.EventTypeName(event.Type),
+			attributeOrgEventsActingUserID: event.ActingUserID,
+			attributeOrgEventsIPAddress:    event.IPAddress,
+			attributeOrgEventsItemID:       event.ItemID,
+			attributeOrgEventsCollectionID: event.CollectionID,
+			attributeOrgEventsGroupID:      event.GroupID,
+			attributeOrgEventsMemberID:     event.MemberID,
+			attributeOrgEventsPolicyID:     event.PolicyID,
+		}
+		if event.Date != nil {
+			m[attributeOrgEventsDate] = event.Date.Format(bw.DateLayout)
+		}
+		if event.Device != nil {
+			m[attributeOrgEventsDevice] = *event.Device
+			m[attributeOrgEventsDeviceName] = orgapi.DeviceTypeName(*event.Device)
+		}
+		eventList = append(eventList, m)
+	}
+
+	id := fmt.Sprintf("%s/%s", filter.Start.Format(time.RFC3339), filter.End.Format(time.RFC3339))
+	if len(filter.ContinuationToken) > 0 {
+		id = fmt.Sprintf("%s/%s", id, filter.ContinuationToken)
+	}
+	d.SetId(id)
+
+	err = d.Set(attributeOrgEvents, eventList)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.FromErr(d.Set(attributeOrgEventsNextToken, nextToken))
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceOrgEvents(t *testing.T) {
+	meta, closeServer := newTestOrgAPIMeta(t, map[string]http.HandlerFunc{
+		"/api/public/events": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "item-1", r.URL.Query().Get("itemId"))
+
+			switch r.URL.Query().Get("continuationToken") {
+			case "":
+				w.Write([]byte(`{"object": "list", "data": [{"type": 1107, "itemId": "item-1", "actingUserId": "user-1", "date": "2024-01-02T10:00:00Z", "device": 25, "ipAddress": "10.0.0.1"}], "continuationToken": "page-2"}`))
+			case "page-2":
+				w.Write([]byte(`{"object": "list", "data": [{"type": 1111, "itemId": "item-1", "actingUserId": "user-2", "collectionId": "coll-1"}], "continuationToken": "page-3"}`))
+			}
+		},
+	})
+	defer closeServer()
+
+	d := dataSourceOrgEvents().TestResourceData()
+	d.Set(attributeOrgEventsStart, "2024-01-01T00:00:00Z")
+	d.Set(attributeOrgEventsEnd, "2024-01-31T00:00:00Z")
+	d.Set(attributeOrgEventsItemID, "item-1")
+	d.Set(attributeOrgEventsMaxPages, 2)
+
+	diags := readDataSourceOrgEvents(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	assert.Equal(t, "page-3", d.Get(attributeOrgEventsNextToken))
+	assert.Equal(t, 2, d.Get("events.#"))
+	assert.Equal(t, "Cipher_ClientViewed", d.Get("events.0.type_name"))
+	assert.Equal(t, "user-1", d.Get("events.0.acting_user_id"))
+	assert.Equal(t, "LinuxCLI", d.Get("events.0.device_name"))
+	assert.Equal(t, "10.0.0.1", d.Get("events.0.ip_address"))
+	assert.Equal(t, "Cipher_ClientCopiedPassword", d.Get("events.1.type_name"))
+	assert.Equal(t, "coll-1", d.Get("events.1.collection_id"))
+}
+
+func TestDataSourceOrgEventsRequiresOrganizationAPIKey(t *testing.T) {
+	d := dataSourceOrgEvents().TestResourceData()
+	d.Set(attributeOrgEventsStart, "2024-01-01T00:00:00Z")
+	d.Set(attributeOrgEventsEnd, "2024-01-31T00:00:00Z")
+
+	diags := readDataSourceOrgEvents(context.Background(), d, &bitwardenClients{})
+
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "organization_api_key")
+	}
+}
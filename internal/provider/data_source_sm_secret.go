@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSMSecret() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get a Secrets Manager secret by identifier or key. Requires the `access_token` attribute to be configured on the provider.",
+		ReadContext: dataSourceSMSecretRead,
+		Schema:      smSecretSchema(DataSource),
+	}
+}
+
+func dataSourceSMSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	secretID := d.Get(attributeID).(string)
+	if len(secretID) == 0 {
+		secretID, err = findSMSecretIDByKey(client, d.Get(attributeSMSecretKey).(string), d.Get(attributeSMProjectID).(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	secret, err := client.GetSecret(secretID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smSecretDataFromStruct(d, secret))
+}
+
+// findSMSecretIDByKey looks for the only secret with the requested key. As
+// listing secrets doesn't return the projects they belong to, every candidate
+// is fetched when filtering by project.
+func findSMSecretIDByKey(client bws.Client, key, projectID string) (string, error) {
+	secrets, err := client.ListSecrets()
+	if err != nil {
+		return "", err
+	}
+
+	matches := []string{}
+	for _, secret := range secrets {
+		if secret.Key != key {
+			continue
+		}
+
+		if len(projectID) > 0 {
+			candidate, err := client.GetSecret(secret.ID)
+			if err != nil {
+				return "", err
+			}
+			if candidate.ProjectID != projectID {
+				continue
+			}
+		}
+		matches = append(matches, secret.ID)
+	}
+
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no secret found with key '%s'", key)
+	} else if len(matches) > 1 {
+		return "", fmt.Errorf("too many secrets found with key '%s' (%d), use 'project_id' or 'id' to narrow down the search", key, len(matches))
+	}
+	return matches[0], nil
+}
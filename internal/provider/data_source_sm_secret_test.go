@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceSMSecretByKey(t *testing.T) {
+	meta, server := newTestSecretsManagerMeta(t)
+	defer server.Close()
+
+	production, err := meta.secretsManager.CreateProject(bws.Project{Name: "Production"})
+	assert.NoError(t, err)
+	staging, err := meta.secretsManager.CreateProject(bws.Project{Name: "Staging"})
+	assert.NoError(t, err)
+
+	_, err = meta.secretsManager.CreateSecret(bws.Secret{Key: "DB_PASSWORD", Value: "production-password", ProjectID: production.ID})
+	assert.NoError(t, err)
+	_, err = meta.secretsManager.CreateSecret(bws.Secret{Key: "DB_PASSWORD", Value: "staging-password", ProjectID: staging.ID})
+	assert.NoError(t, err)
+
+	d := dataSourceSMSecret().TestResourceData()
+	d.Set(attributeSMSecretKey, "DB_PASSWORD")
+
+	diags := dataSourceSMSecretRead(context.Background(), d, meta)
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "too many secrets found with key 'DB_PASSWORD' (2), use 'project_id' or 'id' to narrow down the search", diags[0].Summary)
+	}
+
+	d.Set(attributeSMProjectID, staging.ID)
+	diags = dataSourceSMSecretRead(context.Background(), d, meta)
+	if assert.False(t, diags.HasError(), diags) {
+		assert.Equal(t, "staging-password", d.Get(attributeSMSecretValue))
+	}
+
+	d = dataSourceSMSecret().TestResourceData()
+	d.Set(attributeSMSecretKey, "API_KEY")
+	diags = dataSourceSMSecretRead(context.Background(), d, meta)
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "no secret found with key 'API_KEY'", diags[0].Summary)
+	}
+}
+
+func TestDataSourceSMSecretByID(t *testing.T) {
+	meta, server := newTestSecretsManagerMeta(t)
+	defer server.Close()
+
+	secret, err := meta.secretsManager.CreateSecret(bws.Secret{Key: "API_KEY", Value: "abcd", Note: "Read-only"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	d := dataSourceSMSecret().TestResourceData()
+	d.Set(attributeID, secret.ID)
+
+	diags := dataSourceSMSecretRead(context.Background(), d, meta)
+	if assert.False(t, diags.HasError(), diags) {
+		assert.Equal(t, "API_KEY", d.Get(attributeSMSecretKey))
+		assert.Equal(t, "abcd", d.Get(attributeSMSecretValue))
+		assert.Equal(t, "Read-only", d.Get(attributeSMSecretNote))
+	}
+}
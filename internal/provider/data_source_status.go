@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get information on the Bitwarden CLI and the Vault used by the provider.",
+		ReadContext: readDataSourceStatus,
+		Schema: map[string]*schema.Schema{
+			attributeStatusCLIVersion: {
+				Description: descriptionStatusCLIVersion,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeStatusLastSync: {
+				Description: descriptionStatusLastSync,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeStatusServerURL: {
+				Description: descriptionStatusServerURL,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeStatusStatus: {
+				Description: descriptionStatusStatus,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeStatusUserEmail: {
+				Description: descriptionStatusUserEmail,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeStatusUserID: {
+				Description: descriptionStatusUserID,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func readDataSourceStatus(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	status, err := meta.(bw.Client).Status()
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	// The CLI version can't be retrieved when going through 'bw serve'.
+	cliVersion, err := meta.(bw.Client).Version()
+	if err != nil {
+		log.Printf("[WARN] Unable to retrieve the version of the Bitwarden CLI: %v\n", err)
+		cliVersion = ""
+	}
+
+	if len(status.UserID) > 0 {
+		d.SetId(status.UserID)
+	} else {
+		d.SetId(string(status.Status))
+	}
+
+	values := map[string]interface{}{
+		attributeStatusCLIVersion: cliVersion,
+		attributeStatusServerURL:  status.ServerURL,
+		attributeStatusStatus:     string(status.Status),
+		attributeStatusUserEmail:  status.UserEmail,
+		attributeStatusUserID:     status.UserID,
+	}
+
+	if !status.LastSync.IsZero() {
+		values[attributeStatusLastSync] = status.LastSync.Format(bw.DateLayout)
+	}
+
+	for attribute, value := range values {
+		err = d.Set(attribute, value)
+		if err != nil {
+			return diagFromErr(err)
+		}
+	}
+	return nil
+}
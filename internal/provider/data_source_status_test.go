@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceStatus(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"status":    `{"serverURL": "http://127.0.0.1/", "lastSync": "2024-05-01T10:00:00.000Z", "userEmail": "test@laverse.net", "userId": "user-1234", "status": "unlocked"}`,
+		"--version": "2024.4.1\n",
+	})
+	defer removeMocks(t)
+
+	d := dataSourceStatus().TestResourceData()
+	diags := readDataSourceStatus(context.Background(), d, bw.NewClient("dummy"))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	assert.Equal(t, "user-1234", d.Id())
+	assert.Equal(t, "2024.4.1", d.Get(attributeStatusCLIVersion))
+	assert.Equal(t, "2024-05-01T10:00:00.000Z", d.Get(attributeStatusLastSync))
+	assert.Equal(t, "http://127.0.0.1/", d.Get(attributeStatusServerURL))
+	assert.Equal(t, "unlocked", d.Get(attributeStatusStatus))
+	assert.Equal(t, "test@laverse.net", d.Get(attributeStatusUserEmail))
+}
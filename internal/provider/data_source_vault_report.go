@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/hibp"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/passwordstrength"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Reasons login items are part of the vault report, in the order they are
+// reported in.
+const (
+	vaultReportReasonExposed = "exposed"
+	vaultReportReasonHTTPURI = "http_uri"
+	vaultReportReasonReused  = "reused"
+	vaultReportReasonWeak    = "weak"
+)
+
+// usernameSeparators split usernames into the user inputs passwords are
+// scored against, the way Bitwarden clients do.
+var usernameSeparators = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+func dataSourceVaultReport() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to find login items with reused, weak or exposed passwords, or with URIs using unencrypted HTTP, e.g. to fail a pipeline when a shared collection needs attention. The report only contains identifiers and reasons, never secrets: `exposed` (password found in the Pwned Passwords), `http_uri` (URI using unencrypted HTTP), `reused` (password used by other items of the report) and `weak` (password strength score of at most `weak_password_score`).",
+		ReadContext: readDataSourceVaultReport,
+		Schema: map[string]*schema.Schema{
+			attributeFilterCollectionId: {
+				Description: descriptionFilterCollectionID,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeFilterFolderID: {
+				Description: descriptionFilterFolderID,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeFilterOrganizationID: {
+				Description: descriptionFilterOrganizationID,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeFilterSearch: {
+				Description: descriptionFilterSearch,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeVaultReportPwnedPasswordsPath: {
+				Description: descriptionVaultReportPwnedPasswordsPath,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			attributeVaultReportWeakPasswordScore: {
+				Description:      descriptionVaultReportWeakPasswordScore,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          2,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 4)),
+			},
+			attributeVaultReportItems: {
+				Description: descriptionVaultReportItems,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						attributeID: {
+							Description: descriptionIdentifier,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeName: {
+							Description: descriptionName,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						attributeVaultReportReasons: {
+							Description: descriptionVaultReportReasons,
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readDataSourceVaultReport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	objs, err := meta.(bw.Client).ListObjects(fmt.Sprintf("%ss", bw.ObjectTypeItem), listOptionsFromData(d)...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logins := make([]bw.Object, 0, len(objs))
+	passwordUses := map[string]int{}
+	for _, obj := range bw.FilterObjectsByType(objs, bw.ItemTypeLogin) {
+		if obj.DeletedDate != nil {
+			continue
+		}
+		logins = append(logins, obj)
+		if len(obj.Login.Password) > 0 {
+			passwordUses[obj.Login.Password]++
+		}
+	}
+
+	exposed := map[string]bool{}
+	if path, ok := d.GetOk(attributeVaultReportPwnedPasswordsPath); ok {
+		hashes := make([]string, 0, len(passwordUses))
+		for password := range passwordUses {
+			hashes = append(hashes, hibp.Hash(password))
+		}
+		exposed, err = hibp.Exposed(path.(string), hashes)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	weakPasswordScore := d.Get(attributeVaultReportWeakPasswordScore).(int)
+	items := make([]interface{}, 0)
+	reportHash := sha256.New()
+	for _, obj := range logins {
+		var reasons []string
+		if password := obj.Login.Password; len(password) > 0 {
+			if exposed[hibp.Hash(password)] {
+				reasons = append(reasons, vaultReportReasonExposed)
+			}
+		}
+		for _, uri := range obj.Login.URIs {
+			if strings.HasPrefix(strings.ToLower(uri.URI), "http://") {
+				reasons = append(reasons, vaultReportReasonHTTPURI)
+				break
+			}
+		}
+		if password := obj.Login.Password; len(password) > 0 {
+			if passwordUses[password] > 1 {
+				reasons = append(reasons, vaultReportReasonReused)
+			}
+			if passwordstrength.Score(password, passwordUserInputs(obj.Login.Username)...) <= weakPasswordScore {
+				reasons = append(reasons, vaultReportReasonWeak)
+			}
+		}
+
+		if len(reasons) == 0 {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			attributeID:                 obj.ID,
+			attributeName:               obj.Name,
+			attributeVaultReportReasons: reasons,
+		})
+		fmt.Fprintf(reportHash, "%s:%s\n", obj.ID, strings.Join(reasons, ","))
+	}
+
+	// The identifier only changes with the report, and can't be used to
+	// guess secrets.
+	d.SetId(fmt.Sprintf("%x", reportHash.Sum(nil)))
+	return diag.FromErr(d.Set(attributeVaultReportItems, items))
+}
+
+// passwordUserInputs returns the parts of a username a password shouldn't
+// be based on, e.g. 'john' and 'doe' for 'john.doe@example.com'.
+func passwordUserInputs(username string) []string {
+	username, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(username)), "@")
+	if len(username) == 0 {
+		return nil
+	}
+	return usernameSeparators.Split(username, -1)
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/hibp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceVaultReport(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"list items --organizationid org-1": `[
+			{"id": "item-1", "object": "item", "type": 1, "name": "Database", "login": {"username": "admin", "password": "x7#Kp!2mQz9v", "uris": [{"uri": "https://db.example.com"}]}},
+			{"id": "item-2", "object": "item", "type": 1, "name": "Grafana", "login": {"username": "admin", "password": "x7#Kp!2mQz9v", "uris": [{"uri": "HTTP://grafana.example.com"}]}},
+			{"id": "item-3", "object": "item", "type": 1, "name": "Router", "login": {"username": "jdoe@example.com", "password": "jdoe1990", "uris": [{"uri": "https://192.168.1.1"}]}},
+			{"id": "item-4", "object": "item", "type": 1, "name": "Wiki", "login": {"password": "Wr7!pLq#9vZs2@Kt"}},
+			{"id": "item-5", "object": "item", "type": 1, "name": "Deleted", "deletedDate": "2024-01-01T10:00:00.000Z", "login": {"password": "x7#Kp!2mQz9v"}},
+			{"id": "item-6", "object": "item", "type": 2, "name": "Note", "notes": "password"}
+		]`,
+	})
+	defer removeMocks(t)
+
+	pwnedPasswords := filepath.Join(t.TempDir(), "pwnedpasswords.txt")
+	assert.NoError(t, os.WriteFile(pwnedPasswords, []byte(hibp.Hash("jdoe1990")+":12\n"), 0600))
+
+	d := dataSourceVaultReport().TestResourceData()
+	d.Set(attributeFilterOrganizationID, "org-1")
+	d.Set(attributeVaultReportPwnedPasswordsPath, pwnedPasswords)
+	d.Set(attributeVaultReportWeakPasswordScore, 2)
+
+	diags := readDataSourceVaultReport(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	assert.Len(t, d.Id(), 64)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{attributeID: "item-1", attributeName: "Database", attributeVaultReportReasons: []interface{}{"reused"}},
+		map[string]interface{}{attributeID: "item-2", attributeName: "Grafana", attributeVaultReportReasons: []interface{}{"http_uri", "reused"}},
+		map[string]interface{}{attributeID: "item-3", attributeName: "Router", attributeVaultReportReasons: []interface{}{"exposed", "weak"}},
+	}, d.Get(attributeVaultReportItems))
+	assert.NotContains(t, d.State().String(), "x7#Kp!2mQz9v")
+}
+
+func TestDataSourceVaultReportMissingPwnedPasswords(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"list items": `[{"id": "item-1", "object": "item", "type": 1, "name": "Database", "login": {"password": "password"}}]`,
+	})
+	defer removeMocks(t)
+
+	d := dataSourceVaultReport().TestResourceData()
+	d.Set(attributeVaultReportPwnedPasswordsPath, filepath.Join(t.TempDir(), "missing"))
+
+	diags := readDataSourceVaultReport(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "unable to read Pwned Passwords")
+	}
+}
+
+func TestPasswordUserInputs(t *testing.T) {
+	assert.Equal(t, []string{"john", "doe"}, passwordUserInputs(" John.Doe@example.com "))
+	assert.Equal(t, []string{"admin"}, passwordUserInputs("admin"))
+	assert.Nil(t, passwordUserInputs(""))
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// knownErrorDiagnostics gives actionable summaries and details for errors
+// reported by Bitwarden.
+var knownErrorDiagnostics = []struct {
+	err     error
+	summary string
+	detail  string
+}{
+	{
+		err:     bw.ErrVaultLocked,
+		summary: "Bitwarden Vault is locked",
+		detail:  "The session key is invalid or has expired, or the Vault was locked by another process. Provide a valid 'session_key', or a 'master_password' for the provider to unlock the Vault itself.",
+	},
+	{
+		err:     bw.ErrNotLoggedIn,
+		summary: "Not logged in to Bitwarden",
+		detail:  "The Bitwarden CLI isn't logged in. Provide a 'master_password', together with 'client_id' and 'client_secret' to log in with an API key, or with 'email' to log in with a password.",
+	},
+	{
+		err:     bw.ErrInvalidMasterPassword,
+		summary: "Invalid Bitwarden master password",
+		detail:  "Bitwarden rejected the credentials. Check the 'master_password' (env: BW_PASSWORD) and the 'email' (env: BW_EMAIL) the provider is configured with.",
+	},
+	{
+		err:     bw.ErrTwoFactorRequired,
+		summary: "Bitwarden two-step login required",
+		detail:  "The account has two-step login enabled. Provide the current code with 'two_factor_code', let the provider compute it with 'two_factor_totp_secret', or log in with an API key ('client_id' and 'client_secret') instead.",
+	},
+	{
+		err:     bw.ErrTwoFactorInvalid,
+		summary: "Invalid Bitwarden two-step login code",
+		detail:  "Bitwarden rejected the two-step login code. Codes expire quickly: check that 'two_factor_code' is current, or that 'two_factor_totp_secret' is the secret of the authenticator configured on the account and that the system clock is accurate.",
+	},
+	{
+		err:     bw.ErrPermissionDenied,
+		summary: "Permission denied by Bitwarden",
+		detail:  "The account isn't allowed to perform this operation. Check its role in the organization and the permissions it has on the collections involved.",
+	},
+	{
+		err:     bw.ErrOrganizationDisabled,
+		summary: "Bitwarden organization is disabled",
+		detail:  "The organization owning this object is disabled. Re-enable it from the admin console, or check its subscription and billing status.",
+	},
+	{
+		err:     errRevisionConflict,
+		summary: "Bitwarden item modified outside of Terraform",
+		detail:  "The item changed since Terraform last read it, e.g. from the web vault or another pipeline, and applying the plan would overwrite these changes. Run Terraform again to review them, or set 'on_conflict = \"overwrite\"' on the resource to apply the planned changes on top of the latest version of the item.",
+	},
+}
+
+// diagFromErr is a replacement for diag.FromErr() which translates known
+// Bitwarden errors into diagnostics a user can act upon.
+func diagFromErr(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	for _, known := range knownErrorDiagnostics {
+		if errors.Is(err, known.err) {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  known.summary,
+				Detail:   fmt.Sprintf("%s\n\nError reported by Bitwarden: %v", known.detail, err),
+			}}
+		}
+	}
+	return diag.FromErr(err)
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagFromErr(t *testing.T) {
+	assert.Nil(t, diagFromErr(nil))
+
+	diags := diagFromErr(fmt.Errorf("unable to unlock: %w", &bw.Error{Kind: bw.ErrInvalidMasterPassword, Message: "Invalid master password."}))
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Error, diags[0].Severity)
+		assert.Equal(t, "Invalid Bitwarden master password", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "master_password")
+		assert.Contains(t, diags[0].Detail, "Invalid master password.")
+	}
+
+	diags = diagFromErr(&bw.Error{Kind: bw.ErrTwoFactorInvalid, Message: "Two-step token is invalid. Try again."})
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Invalid Bitwarden two-step login code", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "two_factor_code")
+	}
+
+	diags = diagFromErr(errors.New("something else"))
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "something else", diags[0].Summary)
+	}
+}
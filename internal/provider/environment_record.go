@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+)
+
+// The Bitwarden CLI only reports the base URL of the server it is configured
+// with. To notice changes to the URLs of the other services, the provider
+// records the environment it configured next to the Vault.
+const environmentRecordFileName = "terraform-environment.json"
+
+// loadEnvironmentRecord returns the environment the provider last configured
+// the Vault stored in vaultPath with. Vaults without record reach all
+// services through serverURL.
+func loadEnvironmentRecord(vaultPath, serverURL string) bw.Environment {
+	record := bw.Environment{}
+	data, err := os.ReadFile(filepath.Join(vaultPath, environmentRecordFileName))
+	if err == nil {
+		err = json.Unmarshal(data, &record)
+	}
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("[WARN] Unable to read environment record: %v\n", err)
+		}
+		record = bw.Environment{}
+	}
+
+	record.Server = serverURL
+	return record
+}
+
+// saveEnvironmentRecord records the environment the Vault stored in vaultPath
+// has been configured with. Environments using the server URL for all
+// services don't need a record.
+func saveEnvironmentRecord(vaultPath string, env bw.Environment) error {
+	path := filepath.Join(vaultPath, environmentRecordFileName)
+	if env == (bw.Environment{Server: env.Server}) {
+		err := os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(vaultPath, 0700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
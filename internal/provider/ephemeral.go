@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func ephemeralAttachment() ephemeral.EphemeralResource {
+	return &ephemeralDataSource{
+		typeName:    "attachment",
+		description: "Use this ephemeral resource to get the content on an existing item's attachment, without storing it in the Terraform state.",
+		dataSource:  dataSourceAttachment(),
+	}
+}
+
+func ephemeralItemLogin() ephemeral.EphemeralResource {
+	return &ephemeralDataSource{
+		typeName:    "item_login",
+		description: "Use this ephemeral resource to get information on an existing login item, without storing it in the Terraform state.",
+		dataSource:  dataSourceItemLogin(),
+	}
+}
+
+func ephemeralItemSecureNote() ephemeral.EphemeralResource {
+	return &ephemeralDataSource{
+		typeName:    "item_secure_note",
+		description: "Use this ephemeral resource to get information on an existing secure note item, without storing it in the Terraform state.",
+		dataSource:  dataSourceItemSecureNote(),
+	}
+}
+
+// ephemeralDataSource exposes an SDKv2 data source as an ephemeral resource,
+// sharing its schema, lookup filters and read function.
+type ephemeralDataSource struct {
+	typeName    string
+	description string
+	dataSource  *schema.Resource
+	meta        interface{}
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralDataSource{}
+
+func (e *ephemeralDataSource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, e.typeName)
+}
+
+func (e *ephemeralDataSource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	ephemeralSchema, err := frameworkEphemeralSchema(e.description, e.dataSource.Schema)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to convert the ephemeral resource schema", err.Error())
+		return
+	}
+	resp.Schema = ephemeralSchema
+}
+
+func (e *ephemeralDataSource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.meta = req.ProviderData
+}
+
+func (e *ephemeralDataSource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if e.meta == nil {
+		resp.Diagnostics.AddError("BUG: ephemeral resource isn't configured", "The provider didn't pass its clients to the ephemeral resource.")
+		return
+	}
+
+	var config map[string]tftypes.Value
+	err := req.Config.Raw.As(&config)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read the configuration", err.Error())
+		return
+	}
+
+	// Only inputs of the data source are copied, everything else is computed.
+	raw := map[string]interface{}{}
+	for name, s := range e.dataSource.Schema {
+		if !s.Optional && !s.Required {
+			continue
+		}
+
+		v, err := sdkValueFromTerraform(s, config[name])
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to read '%s'", name), err.Error())
+			return
+		} else if v != nil {
+			raw[name] = v
+		}
+	}
+
+	appendSDKDiagnostics(&resp.Diagnostics, e.dataSource.Validate(terraform.NewResourceConfigRaw(raw)))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d := e.dataSource.Data(nil)
+	for name, v := range raw {
+		err := d.Set(name, v)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to set '%s'", name), err.Error())
+			return
+		}
+	}
+
+	appendSDKDiagnostics(&resp.Diagnostics, e.dataSource.ReadContext(ctx, d, e.meta))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, s := range e.dataSource.Schema {
+		value, err := frameworkValueFromSDK(s, d.Get(name))
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to convert '%s'", name), err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.Result.SetAttribute(ctx, path.Root(name), value)...)
+	}
+}
+
+// sdkValueFromTerraform returns the value of a primitive attribute in the
+// form used by the SDKv2, or nil when it isn't set.
+func sdkValueFromTerraform(s *schema.Schema, value tftypes.Value) (interface{}, error) {
+	if !value.IsKnown() || value.IsNull() {
+		return nil, nil
+	}
+
+	switch s.Type {
+	case schema.TypeString:
+		var v string
+		err := value.As(&v)
+		return v, err
+	case schema.TypeBool:
+		var v bool
+		err := value.As(&v)
+		return v, err
+	case schema.TypeInt:
+		var v big.Float
+		err := value.As(&v)
+		i, _ := v.Int64()
+		return int(i), err
+	}
+	return nil, fmt.Errorf("unsupported type of input: %s", s.Type)
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEphemeralItemLogin(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"list items --search Database": `[{"id": "item-1", "object": "item", "name": "Database", "type": 1, "login": {"username": "admin", "password": "s3cr3t"}, "fields": [{"name": "pin", "value": "1234", "type": 1}]}]`,
+	})
+	defer removeMocks(t)
+
+	resp := openTestEphemeralResource(t, ephemeralItemLogin(), map[string]tftypes.Value{
+		attributeFilterSearch: tftypes.NewValue(tftypes.String, "Database"),
+	})
+	if !assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics) {
+		return
+	}
+
+	var id, password types.String
+	var fields types.List
+	resp.Result.GetAttribute(context.Background(), path.Root(attributeID), &id)
+	resp.Result.GetAttribute(context.Background(), path.Root(attributeLoginPassword), &password)
+	resp.Result.GetAttribute(context.Background(), path.Root(attributeField), &fields)
+
+	assert.Equal(t, "item-1", id.ValueString())
+	assert.Equal(t, "s3cr3t", password.ValueString())
+	assert.Len(t, fields.Elements(), 1)
+}
+
+func TestEphemeralItemLoginWithoutFilter(t *testing.T) {
+	resp := openTestEphemeralResource(t, ephemeralItemLogin(), map[string]tftypes.Value{})
+
+	if assert.True(t, resp.Diagnostics.HasError()) {
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "one of `id,search` must be specified")
+	}
+}
+
+func TestEphemeralAttachment(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"get attachment attachment-1 --itemid item-1 --raw": `attachment content`,
+	})
+	defer removeMocks(t)
+
+	resp := openTestEphemeralResource(t, ephemeralAttachment(), map[string]tftypes.Value{
+		attributeID:               tftypes.NewValue(tftypes.String, "attachment-1"),
+		attributeAttachmentItemID: tftypes.NewValue(tftypes.String, "item-1"),
+	})
+	if !assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics) {
+		return
+	}
+
+	var content types.String
+	resp.Result.GetAttribute(context.Background(), path.Root(attributeAttachmentContent), &content)
+	assert.Equal(t, "attachment content", content.ValueString())
+}
+
+// openTestEphemeralResource opens an ephemeral resource configured with the
+// given attributes, all others being null.
+func openTestEphemeralResource(t *testing.T, e ephemeral.EphemeralResource, attributes map[string]tftypes.Value) *ephemeral.OpenResponse {
+	ctx := context.Background()
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	e.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	if !assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics) {
+		t.FailNow()
+	}
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := attributes[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	e.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{
+		ProviderData: bw.NewClient("dummy", bw.DisableRetryBackoff()),
+	}, &ephemeral.ConfigureResponse{})
+
+	resp := &ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	e.Open(ctx, ephemeral.OpenRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, resp)
+	return resp
+}
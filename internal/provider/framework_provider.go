@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewMuxServer combines the SDKv2 provider with a plugin framework provider,
+// which serves the features the SDKv2 doesn't support.
+func NewMuxServer(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	sdkProvider := New(version)()
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(newFrameworkProvider(version, sdkProvider)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider is the plugin framework side of the provider. As the
+// mux server configures the SDKv2 provider first, it reuses its clients
+// instead of configuring its own.
+type frameworkProvider struct {
+	version     string
+	sdkProvider *schema.Provider
+}
+
+var (
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+)
+
+func newFrameworkProvider(version string, sdkProvider *schema.Provider) provider.Provider {
+	return &frameworkProvider{version: version, sdkProvider: sdkProvider}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "bitwarden"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	// Both providers must expose the same schema, the SDKv2 one is the reference.
+	providerSchema, err := frameworkProviderSchema(p.sdkProvider.Schema)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to convert the provider schema", err.Error())
+		return
+	}
+	resp.Schema = providerSchema
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	meta := p.sdkProvider.Meta()
+	if meta == nil {
+		resp.Diagnostics.AddError("BUG: provider isn't configured", "The SDKv2 provider must be configured before the plugin framework provider.")
+		return
+	}
+	resp.EphemeralResourceData = meta
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		ephemeralAttachment,
+		ephemeralItemLogin,
+		ephemeralItemSecureNote,
+	}
+}
+
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newFunctionField,
+		newFunctionTOTPCode,
+		newFunctionURIMatches,
+	}
+}
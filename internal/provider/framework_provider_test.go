@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMuxServerSchema(t *testing.T) {
+	muxServer, err := NewMuxServer(context.Background(), versionDev)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp, err := muxServer().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, d := range resp.Diagnostics {
+		assert.NotEqual(t, tfprotov5.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+
+	assert.Contains(t, resp.ResourceSchemas, "bitwarden_item_login")
+	assert.Contains(t, resp.EphemeralResourceSchemas, "bitwarden_attachment")
+	assert.Contains(t, resp.EphemeralResourceSchemas, "bitwarden_item_login")
+	assert.Contains(t, resp.EphemeralResourceSchemas, "bitwarden_item_secure_note")
+	assert.Contains(t, resp.Functions, "field")
+	assert.Contains(t, resp.Functions, "totp_code")
+	assert.Contains(t, resp.Functions, "uri_matches")
+}
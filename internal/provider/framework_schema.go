@@ -0,0 +1,323 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	frameworkdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+* The plugin framework provider is built from the SDKv2 one: these helpers
+* convert SDKv2 schemas, values and diagnostics to their plugin framework
+* equivalents.
+ */
+
+// frameworkProviderSchema converts the schema of the SDKv2 provider, which
+// must be reproduced exactly by the plugin framework provider. Lists of
+// resources are blocks, like the SDKv2 exposes them.
+func frameworkProviderSchema(sdkSchema map[string]*schema.Schema) (providerschema.Schema, error) {
+	attributes, blocks, err := frameworkProviderAttributes(sdkSchema)
+	if err != nil {
+		return providerschema.Schema{}, err
+	}
+	return providerschema.Schema{Attributes: attributes, Blocks: blocks}, nil
+}
+
+func frameworkProviderAttributes(sdkSchema map[string]*schema.Schema) (map[string]providerschema.Attribute, map[string]providerschema.Block, error) {
+	attributes := map[string]providerschema.Attribute{}
+	blocks := map[string]providerschema.Block{}
+
+	for name, s := range sdkSchema {
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			nestedAttributes, nestedBlocks, err := frameworkProviderAttributes(elem.Schema)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			blocks[name] = providerschema.ListNestedBlock{
+				MarkdownDescription: s.Description,
+				DeprecationMessage:  s.Deprecated,
+				NestedObject: providerschema.NestedBlockObject{
+					Attributes: nestedAttributes,
+					Blocks:     nestedBlocks,
+				},
+			}
+			continue
+		}
+
+		required, optional := sdkRequiredOptional(s)
+
+		switch s.Type {
+		case schema.TypeString:
+			attributes[name] = providerschema.StringAttribute{
+				MarkdownDescription: s.Description,
+				DeprecationMessage:  s.Deprecated,
+				Required:            required,
+				Optional:            optional,
+				Sensitive:           s.Sensitive,
+			}
+		case schema.TypeBool:
+			attributes[name] = providerschema.BoolAttribute{
+				MarkdownDescription: s.Description,
+				DeprecationMessage:  s.Deprecated,
+				Required:            required,
+				Optional:            optional,
+				Sensitive:           s.Sensitive,
+			}
+		case schema.TypeInt:
+			attributes[name] = providerschema.Int64Attribute{
+				MarkdownDescription: s.Description,
+				DeprecationMessage:  s.Deprecated,
+				Required:            required,
+				Optional:            optional,
+				Sensitive:           s.Sensitive,
+			}
+		case schema.TypeList:
+			elemType, err := frameworkTypeFromSDK(s.Elem)
+			if err != nil {
+				return nil, nil, fmt.Errorf("attribute '%s': %w", name, err)
+			}
+			attributes[name] = providerschema.ListAttribute{
+				ElementType:         elemType,
+				MarkdownDescription: s.Description,
+				DeprecationMessage:  s.Deprecated,
+				Required:            required,
+				Optional:            optional,
+				Sensitive:           s.Sensitive,
+			}
+		default:
+			return nil, nil, fmt.Errorf("attribute '%s': unsupported type %s", name, s.Type)
+		}
+	}
+	return attributes, blocks, nil
+}
+
+// sdkRequiredOptional returns whether an attribute is required or optional
+// the way the SDKv2 reports it to Terraform: required attributes with a
+// default value from the environment are optional.
+func sdkRequiredOptional(s *schema.Schema) (bool, bool) {
+	if s.Required && s.DefaultFunc != nil {
+		v, err := s.DefaultFunc()
+		if err != nil || v != nil {
+			return false, true
+		}
+	}
+	return s.Required, s.Optional
+}
+
+// frameworkEphemeralSchema converts the schema of an SDKv2 data source into
+// the one of an ephemeral resource. Lists of resources are always computed
+// in data sources, so they become lists of objects.
+func frameworkEphemeralSchema(description string, sdkSchema map[string]*schema.Schema) (ephemeralschema.Schema, error) {
+	attributes, err := frameworkEphemeralAttributes(sdkSchema)
+	if err != nil {
+		return ephemeralschema.Schema{}, err
+	}
+	return ephemeralschema.Schema{MarkdownDescription: description, Attributes: attributes}, nil
+}
+
+func frameworkEphemeralAttributes(sdkSchema map[string]*schema.Schema) (map[string]ephemeralschema.Attribute, error) {
+	attributes := map[string]ephemeralschema.Attribute{}
+
+	for name, s := range sdkSchema {
+
+		switch s.Type {
+		case schema.TypeString:
+			attributes[name] = ephemeralschema.StringAttribute{
+				MarkdownDescription: s.Description,
+				Required:            s.Required,
+				Optional:            s.Optional,
+				Computed:            s.Computed,
+				Sensitive:           s.Sensitive,
+			}
+		case schema.TypeBool:
+			attributes[name] = ephemeralschema.BoolAttribute{
+				MarkdownDescription: s.Description,
+				Required:            s.Required,
+				Optional:            s.Optional,
+				Computed:            s.Computed,
+				Sensitive:           s.Sensitive,
+			}
+		case schema.TypeInt:
+			attributes[name] = ephemeralschema.Int64Attribute{
+				MarkdownDescription: s.Description,
+				Required:            s.Required,
+				Optional:            s.Optional,
+				Computed:            s.Computed,
+				Sensitive:           s.Sensitive,
+			}
+		case schema.TypeList:
+			elemType, err := frameworkTypeFromSDK(s.Elem)
+			if err != nil {
+				return nil, fmt.Errorf("attribute '%s': %w", name, err)
+			}
+			attributes[name] = ephemeralschema.ListAttribute{
+				ElementType:         elemType,
+				MarkdownDescription: s.Description,
+				Required:            s.Required,
+				Optional:            s.Optional,
+				Computed:            s.Computed,
+				Sensitive:           s.Sensitive,
+			}
+		case schema.TypeSet:
+			elemType, err := frameworkTypeFromSDK(s.Elem)
+			if err != nil {
+				return nil, fmt.Errorf("attribute '%s': %w", name, err)
+			}
+			attributes[name] = ephemeralschema.SetAttribute{
+				ElementType:         elemType,
+				MarkdownDescription: s.Description,
+				Required:            s.Required,
+				Optional:            s.Optional,
+				Computed:            s.Computed,
+				Sensitive:           s.Sensitive,
+			}
+		default:
+			return nil, fmt.Errorf("attribute '%s': unsupported type %s", name, s.Type)
+		}
+	}
+	return attributes, nil
+}
+
+// frameworkTypeFromSDK returns the type of the values of an SDKv2 schema,
+// given as the Elem of a list or a set.
+func frameworkTypeFromSDK(elem interface{}) (attr.Type, error) {
+	switch e := elem.(type) {
+	case *schema.Resource:
+		attrTypes := map[string]attr.Type{}
+		for name, s := range e.Schema {
+			t, err := frameworkTypeFromSDK(s)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[name] = t
+		}
+		return types.ObjectType{AttrTypes: attrTypes}, nil
+
+	case *schema.Schema:
+		switch e.Type {
+		case schema.TypeString:
+			return types.StringType, nil
+		case schema.TypeBool:
+			return types.BoolType, nil
+		case schema.TypeInt:
+			return types.Int64Type, nil
+		case schema.TypeList:
+			elemType, err := frameworkTypeFromSDK(e.Elem)
+			if err != nil {
+				return nil, err
+			}
+			return types.ListType{ElemType: elemType}, nil
+		case schema.TypeSet:
+			elemType, err := frameworkTypeFromSDK(e.Elem)
+			if err != nil {
+				return nil, err
+			}
+			return types.SetType{ElemType: elemType}, nil
+		}
+		return nil, fmt.Errorf("unsupported type %s", e.Type)
+	}
+	return nil, fmt.Errorf("unsupported element %T", elem)
+}
+
+// frameworkValueFromSDK converts a value read from SDKv2 resource data.
+func frameworkValueFromSDK(s *schema.Schema, value interface{}) (attr.Value, error) {
+	switch s.Type {
+	case schema.TypeString:
+		return types.StringValue(value.(string)), nil
+	case schema.TypeBool:
+		return types.BoolValue(value.(bool)), nil
+	case schema.TypeInt:
+		return types.Int64Value(int64(value.(int))), nil
+	case schema.TypeList:
+		elemType, err := frameworkTypeFromSDK(s.Elem)
+		if err != nil {
+			return nil, err
+		}
+
+		elems := []attr.Value{}
+		for _, v := range value.([]interface{}) {
+			elem, err := frameworkElemValueFromSDK(s.Elem, v)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		}
+
+		list, diags := types.ListValue(elemType, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diags.Errors()[0].Detail())
+		}
+		return list, nil
+	case schema.TypeSet:
+		elemType, err := frameworkTypeFromSDK(s.Elem)
+		if err != nil {
+			return nil, err
+		}
+
+		elems := []attr.Value{}
+		for _, v := range value.(*schema.Set).List() {
+			elem, err := frameworkElemValueFromSDK(s.Elem, v)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		}
+
+		set, diags := types.SetValue(elemType, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diags.Errors()[0].Detail())
+		}
+		return set, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", s.Type)
+}
+
+func frameworkElemValueFromSDK(elem interface{}, value interface{}) (attr.Value, error) {
+	r, ok := elem.(*schema.Resource)
+	if !ok {
+		return frameworkValueFromSDK(elem.(*schema.Schema), value)
+	}
+
+	objType, err := frameworkTypeFromSDK(r)
+	if err != nil {
+		return nil, err
+	}
+
+	m, _ := value.(map[string]interface{})
+	attrs := map[string]attr.Value{}
+	for name, s := range r.Schema {
+		v, ok := m[name]
+		if !ok || v == nil {
+			v = s.ZeroValue()
+		}
+
+		attrs[name], err = frameworkValueFromSDK(s, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	obj, diags := types.ObjectValue(objType.(types.ObjectType).AttrTypes, attrs)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags.Errors()[0].Detail())
+	}
+	return obj, nil
+}
+
+// appendSDKDiagnostics adds SDKv2 diagnostics to plugin framework ones.
+func appendSDKDiagnostics(diags *frameworkdiag.Diagnostics, sdkDiags diag.Diagnostics) {
+	for _, d := range sdkDiags {
+		if d.Severity == diag.Error {
+			diags.AddError(d.Summary, d.Detail)
+		} else {
+			diags.AddWarning(d.Summary, d.Detail)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// functionField returns the value of a custom field picked by name, from an
+// item or directly from its list of fields.
+type functionField struct{}
+
+var _ function.Function = &functionField{}
+
+func newFunctionField() function.Function {
+	return &functionField{}
+}
+
+func (f *functionField) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "field"
+}
+
+func (f *functionField) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the value of a custom field",
+		MarkdownDescription: "Returns the value of the custom field named `name`, either from an item (resource, data source or ephemeral resource) or directly from its `field` list. Boolean fields are returned as `true` or `false`.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "item",
+				MarkdownDescription: "Item with a `field` attribute, or list of custom fields.",
+			},
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Name of the custom field.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionField) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var item types.Dynamic
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &item, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	vList, err := fieldListFromDynamic(ctx, item)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	var matches []string
+	for _, field := range objectFieldStructFromData(vList) {
+		if field.Name == name {
+			matches = append(matches, field.Value)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("no custom field named '%s'", name))
+		return
+	case 1:
+	default:
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%d custom fields are named '%s'", len(matches), name))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, matches[0]))
+}
+
+// fieldListFromDynamic converts the custom fields of an item to the format
+// used by the SDKv2 schema, so they can be parsed with
+// objectFieldStructFromData.
+func fieldListFromDynamic(ctx context.Context, item types.Dynamic) ([]interface{}, error) {
+	if item.IsNull() || item.IsUnknown() || item.IsUnderlyingValueNull() || item.IsUnderlyingValueUnknown() {
+		return nil, fmt.Errorf("item must be known and not null")
+	}
+
+	value, err := item.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if value.Type().Is(tftypes.Object{}) {
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+		fields, ok := attributes[attributeField]
+		if !ok {
+			return nil, fmt.Errorf("item has no '%s' attribute", attributeField)
+		}
+		value = fields
+	}
+
+	var elements []tftypes.Value
+	if !value.Type().Is(tftypes.List{}) && !value.Type().Is(tftypes.Tuple{}) && !value.Type().Is(tftypes.Set{}) {
+		return nil, fmt.Errorf("expected an item or a list of custom fields, got: %s", value.Type())
+	}
+	if err := value.As(&elements); err != nil {
+		return nil, err
+	}
+
+	vList := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		var attributes map[string]tftypes.Value
+		if err := element.As(&attributes); err != nil {
+			return nil, fmt.Errorf("expected custom fields to be objects: %w", err)
+		}
+
+		field := map[string]interface{}{}
+		for k, v := range attributes {
+			if !v.IsKnown() || v.IsNull() {
+				continue
+			}
+			switch {
+			case v.Type().Is(tftypes.String):
+				var s string
+				_ = v.As(&s)
+				field[k] = s
+			case v.Type().Is(tftypes.Bool):
+				var b bool
+				_ = v.As(&b)
+				field[k] = b
+			}
+		}
+		if _, ok := field[attributeFieldName].(string); !ok {
+			return nil, fmt.Errorf("custom fields must have a '%s'", attributeFieldName)
+		}
+		vList = append(vList, field)
+	}
+	return vList, nil
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionField(t *testing.T) {
+	fields := testFieldList(t,
+		testFieldObject(t, "pin", "1234", "", nil),
+		testFieldObject(t, "api_key", "", "s3cr3t", nil),
+		testFieldObject(t, "enabled", "", "", types.BoolValue(true)),
+	)
+	item, diags := types.ObjectValue(
+		map[string]attr.Type{attributeID: types.StringType, attributeField: fields.Type(context.Background())},
+		map[string]attr.Value{attributeID: types.StringValue("item-1"), attributeField: fields},
+	)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	testCases := map[string]struct {
+		item     attr.Value
+		name     string
+		expected string
+	}{
+		"text from item":    {item: item, name: "pin", expected: "1234"},
+		"hidden from item":  {item: item, name: "api_key", expected: "s3cr3t"},
+		"boolean from list": {item: fields, name: "enabled", expected: "true"},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := runTestFunction(t, newFunctionField(), types.StringUnknown(), types.DynamicValue(test.item), types.StringValue(test.name))
+			if assert.Nil(t, resp.Error) {
+				assert.Equal(t, types.StringValue(test.expected), resp.Result.Value())
+			}
+		})
+	}
+}
+
+func TestFunctionFieldErrors(t *testing.T) {
+	fields := testFieldList(t,
+		testFieldObject(t, "pin", "1234", "", nil),
+		testFieldObject(t, "pin", "5678", "", nil),
+	)
+
+	resp := runTestFunction(t, newFunctionField(), types.StringUnknown(), types.DynamicValue(fields), types.StringValue("missing"))
+	assert.Equal(t, function.NewArgumentFuncError(1, "no custom field named 'missing'"), resp.Error)
+
+	resp = runTestFunction(t, newFunctionField(), types.StringUnknown(), types.DynamicValue(fields), types.StringValue("pin"))
+	assert.Equal(t, function.NewArgumentFuncError(1, "2 custom fields are named 'pin'"), resp.Error)
+
+	resp = runTestFunction(t, newFunctionField(), types.StringUnknown(), types.DynamicValue(types.StringValue("pin")), types.StringValue("pin"))
+	assert.Equal(t, function.NewArgumentFuncError(0, "expected an item or a list of custom fields, got: tftypes.String"), resp.Error)
+}
+
+func testFieldObject(t *testing.T, name, text, hidden string, boolean attr.Value) attr.Value {
+	if boolean == nil {
+		boolean = types.BoolNull()
+	}
+	textValue, hiddenValue := types.StringNull(), types.StringNull()
+	if len(text) > 0 {
+		textValue = types.StringValue(text)
+	}
+	if len(hidden) > 0 {
+		hiddenValue = types.StringValue(hidden)
+	}
+
+	obj, diags := types.ObjectValue(testFieldAttributeTypes, map[string]attr.Value{
+		attributeFieldName:    types.StringValue(name),
+		attributeFieldText:    textValue,
+		attributeFieldHidden:  hiddenValue,
+		attributeFieldBoolean: boolean,
+	})
+	assert.False(t, diags.HasError(), diags)
+	return obj
+}
+
+func testFieldList(t *testing.T, elements ...attr.Value) types.List {
+	list, diags := types.ListValue(types.ObjectType{AttrTypes: testFieldAttributeTypes}, elements)
+	assert.False(t, diags.HasError(), diags)
+	return list
+}
+
+var testFieldAttributeTypes = map[string]attr.Type{
+	attributeFieldName:    types.StringType,
+	attributeFieldText:    types.StringType,
+	attributeFieldHidden:  types.StringType,
+	attributeFieldBoolean: types.BoolType,
+}
+
+func runTestFunction(t *testing.T, f function.Function, result attr.Value, args ...attr.Value) *function.RunResponse {
+	t.Helper()
+
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/totp"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// functionTOTPCode computes the one-time password of a TOTP seed, as stored
+// in the 'totp' attribute of login items, at a given time. Terraform expects
+// functions to be pure, so the time is an argument rather than the clock.
+type functionTOTPCode struct{}
+
+var _ function.Function = &functionTOTPCode{}
+
+func newFunctionTOTPCode() function.Function {
+	return &functionTOTPCode{}
+}
+
+func (f *functionTOTPCode) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "totp_code"
+}
+
+func (f *functionTOTPCode) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Computes a TOTP code",
+		MarkdownDescription: "Computes the time-based one-time password of a seed, as stored in the `totp` attribute of login items, at a given time. Terraform requires functions to return the same result at plan and apply, so the time is an argument: use `plantimestamp()` for the code of the current run.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "seed",
+				MarkdownDescription: "Base32 secret, `otpauth://totp/...` URI or `steam://<secret>` URI.",
+			},
+			function.StringParameter{
+				Name:                "timestamp",
+				MarkdownDescription: "Time of the code, in RFC 3339 format, e.g. from `plantimestamp()`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionTOTPCode) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed, timestamp string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &seed, &timestamp))
+	if resp.Error != nil {
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid RFC 3339 timestamp: %v", err))
+		return
+	}
+
+	code, err := totp.GenerateCode(seed, at)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, code))
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionTOTPCode(t *testing.T) {
+	f := &functionTOTPCode{}
+	seed := types.StringValue("otpauth://totp/Example:alice@example.com?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+
+	resp := runTestFunction(t, f, types.StringUnknown(), seed, types.StringValue("1970-01-01T00:00:59Z"))
+	if assert.Nil(t, resp.Error) {
+		assert.Equal(t, types.StringValue("287082"), resp.Result.Value())
+	}
+
+	resp = runTestFunction(t, f, types.StringUnknown(), types.StringValue("otpauth://hotp/Example?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"), types.StringValue("1970-01-01T00:00:59Z"))
+	if assert.NotNil(t, resp.Error) && assert.NotNil(t, resp.Error.FunctionArgument) {
+		assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+	}
+
+	resp = runTestFunction(t, f, types.StringUnknown(), seed, types.StringValue("59"))
+	if assert.NotNil(t, resp.Error) && assert.NotNil(t, resp.Error.FunctionArgument) {
+		assert.Equal(t, int64(1), *resp.Error.FunctionArgument)
+	}
+}
+
+func TestFunctionTOTPCodeIsDeterministic(t *testing.T) {
+	f := &functionTOTPCode{}
+	seed := types.StringValue("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	timestamp := types.StringValue("2024-05-01T12:00:00Z")
+
+	first := runTestFunction(t, f, types.StringUnknown(), seed, timestamp)
+	if !assert.Nil(t, first.Error) {
+		t.FailNow()
+	}
+
+	// The result must not depend on when the function runs, as Terraform
+	// rejects functions returning different results at plan and apply.
+	for i := 0; i < 3; i++ {
+		resp := runTestFunction(t, f, types.StringUnknown(), seed, timestamp)
+		if assert.Nil(t, resp.Error) {
+			assert.Equal(t, first.Result.Value(), resp.Result.Value())
+		}
+	}
+}
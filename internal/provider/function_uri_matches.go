@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/urimatch"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// functionURIMatches tells whether a URL matches the URI of a login item,
+// according to its match detection mode.
+type functionURIMatches struct{}
+
+var _ function.Function = &functionURIMatches{}
+
+func newFunctionURIMatches() function.Function {
+	return &functionURIMatches{}
+}
+
+func (f *functionURIMatches) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "uri_matches"
+}
+
+func (f *functionURIMatches) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Tells whether a URL matches a login URI",
+		MarkdownDescription: "Tells whether a URL matches the URI of a login item, following the match detection semantics of Bitwarden clients, including their global equivalent domains. Regular expressions use the RE2 syntax of Go, which lacks some JavaScript features supported by Bitwarden clients, like lookarounds or backreferences.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "uri",
+				MarkdownDescription: "URI of the login item.",
+			},
+			function.StringParameter{
+				Name:                "match",
+				MarkdownDescription: "URI match detection mode, as in the `match` attribute of login URIs: `" + strings.Join(validURIMatchStr, "`, `") + "`.",
+			},
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "URL to test.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *functionURIMatches) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var uri, match, rawURL string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &uri, &match, &rawURL))
+	if resp.Error != nil {
+		return
+	}
+
+	if !slices.Contains(validURIMatchStr, match) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unsupported URI match '%s', expected one of: %s", match, strings.Join(validURIMatchStr, ", ")))
+		return
+	}
+
+	loginURI := bw.LoginURI{Match: strMatchToInt(match), URI: uri}
+	matches, err := urimatch.New(bw.URIMatchBaseDomain).Matches(loginURI, rawURL)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, matches))
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionURIMatches(t *testing.T) {
+	testCases := []struct {
+		uri      string
+		match    URIMatchStr
+		url      string
+		expected bool
+	}{
+		{uri: "https://example.co.uk", match: URIMatchDefault, url: "https://login.example.co.uk/path", expected: true},
+		{uri: "example.co.uk", match: URIMatchBaseDomain, url: "https://other.co.uk", expected: false},
+		{uri: "https://www.icloud.com", match: URIMatchBaseDomain, url: "https://appleid.apple.com", expected: true},
+		{uri: "https://192.168.1.1:8443", match: URIMatchBaseDomain, url: "http://192.168.1.1", expected: true},
+		{uri: "https://example.com:8443", match: URIMatchHost, url: "https://example.com:8443/login", expected: true},
+		{uri: "https://example.com:8443", match: URIMatchHost, url: "https://example.com/login", expected: false},
+		{uri: "https://www.example.com", match: URIMatchHost, url: "https://example.com", expected: false},
+		{uri: "https://example.com/app", match: URIMatchStartWith, url: "https://example.com/app/login", expected: true},
+		{uri: "https://example.com/app", match: URIMatchStartWith, url: "https://example.com/other", expected: false},
+		{uri: "https://example.com/login", match: URIMatchExact, url: "https://example.com/login", expected: true},
+		{uri: "https://example.com/login", match: URIMatchExact, url: "https://example.com/login?next=/", expected: false},
+		{uri: `^https://[a-z]+\.example\.com/`, match: URIMatchRegExp, url: "https://APP.example.com/", expected: true},
+		{uri: "https://example.com", match: URIMatchNever, url: "https://example.com", expected: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%s %s %s", test.uri, test.match, test.url), func(t *testing.T) {
+			resp := runTestFunction(t, newFunctionURIMatches(), types.BoolUnknown(), types.StringValue(test.uri), types.StringValue(string(test.match)), types.StringValue(test.url))
+			if assert.Nil(t, resp.Error) {
+				assert.Equal(t, types.BoolValue(test.expected), resp.Result.Value())
+			}
+		})
+	}
+}
+
+func TestFunctionURIMatchesErrors(t *testing.T) {
+	resp := runTestFunction(t, newFunctionURIMatches(), types.BoolUnknown(), types.StringValue("example.com"), types.StringValue("domain"), types.StringValue("https://example.com"))
+	assert.Equal(t, function.NewArgumentFuncError(1, "unsupported URI match 'domain', expected one of: default, base_domain, host, start_with, exact, regexp, never"), resp.Error)
+
+	resp = runTestFunction(t, newFunctionURIMatches(), types.BoolUnknown(), types.StringValue("https://(example.com"), types.StringValue("regexp"), types.StringValue("https://example.com"))
+	if assert.NotNil(t, resp.Error) {
+		assert.Contains(t, resp.Error.Text, "invalid regular expression 'https://(example.com'")
+	}
+}
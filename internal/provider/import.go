@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+)
+
+const (
+	importPrefixName       = "name:"
+	importPrefixFolder     = "folder:"
+	importPrefixCollection = "collection:"
+)
+
+// resolveImportID translates a human-friendly import identifier into the
+// identifier of the matching item. Plain identifiers are returned as-is.
+//
+// Supported formats:
+// - name:<item name>
+// - folder:<folder path>/<item name>
+// - collection:<organization id>/<collection path>/<item name>
+func resolveImportID(client bw.Client, importID string, itemType bw.ItemType) (string, error) {
+	switch {
+	case strings.HasPrefix(importID, importPrefixName):
+		itemName := strings.TrimPrefix(importID, importPrefixName)
+		return findItemIDByName(client, itemType, itemName)
+
+	case strings.HasPrefix(importID, importPrefixFolder):
+		// Nested folders are named after their path, e.g. 'Parent/Child', so
+		// the item name starts after the last slash.
+		path := strings.TrimPrefix(importID, importPrefixFolder)
+		lastSlash := strings.LastIndex(path, "/")
+		if lastSlash <= 0 || lastSlash == len(path)-1 {
+			return "", fmt.Errorf("invalid ID specified, should be in the format folder:<folder_path>/<item_name>: '%s'", importID)
+		}
+
+		folderName, itemName := path[:lastSlash], path[lastSlash+1:]
+		folderID, err := findObjectIDByName(client, bw.ObjectTypeFolder, folderName)
+		if err != nil {
+			return "", fmt.Errorf("unable to find folder '%s': %w", folderName, err)
+		}
+		return findItemIDByName(client, itemType, itemName, bw.WithFolderID(folderID))
+
+	case strings.HasPrefix(importID, importPrefixCollection):
+		path := strings.TrimPrefix(importID, importPrefixCollection)
+		firstSlash := strings.Index(path, "/")
+		lastSlash := strings.LastIndex(path, "/")
+		if firstSlash <= 0 || lastSlash == firstSlash || lastSlash == len(path)-1 {
+			return "", fmt.Errorf("invalid ID specified, should be in the format collection:<organization_id>/<collection_path>/<item_name>: '%s'", importID)
+		}
+
+		orgID := path[:firstSlash]
+		collectionPath := path[firstSlash+1 : lastSlash]
+		itemName := path[lastSlash+1:]
+
+		collectionID, err := findObjectIDByName(client, bw.ObjectTypeOrgCollection, collectionPath, bw.WithOrganizationID(orgID))
+		if err != nil {
+			return "", fmt.Errorf("unable to find collection '%s' in organization '%s': %w", collectionPath, orgID, err)
+		}
+		return findItemIDByName(client, itemType, itemName, bw.WithOrganizationID(orgID), bw.WithCollectionID(collectionID))
+	}
+
+	return importID, nil
+}
+
+func findItemIDByName(client bw.Client, itemType bw.ItemType, name string, options ...bw.ListObjectsOption) (string, error) {
+	objs, err := client.ListObjects(fmt.Sprintf("%ss", bw.ObjectTypeItem), append(options, bw.WithSearch(name))...)
+	if err != nil {
+		return "", err
+	}
+
+	return uniqueObjectIDByName(bw.FilterObjectsByType(objs, itemType), name)
+}
+
+func findObjectIDByName(client bw.Client, objType bw.ObjectType, name string, options ...bw.ListObjectsOption) (string, error) {
+	objs, err := client.ListObjects(fmt.Sprintf("%ss", objType), append(options, bw.WithSearch(name))...)
+	if err != nil {
+		return "", err
+	}
+
+	return uniqueObjectIDByName(objs, name)
+}
+
+// uniqueObjectIDByName only keeps objects whose name is an exact match, as the
+// '--search' flag of the Bitwarden CLI also matches on substrings and other
+// attributes.
+func uniqueObjectIDByName(objs []bw.Object, name string) (string, error) {
+	matches := make([]bw.Object, 0, len(objs))
+	for _, obj := range objs {
+		if obj.Name == name && obj.DeletedDate == nil {
+			matches = append(matches, obj)
+		}
+	}
+
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no object found with name '%s'", name)
+	} else if len(matches) > 1 {
+		log.Printf("[WARN] Too many objects found with name '%s':", name)
+		for _, obj := range matches {
+			log.Printf("[WARN] * %s (%s)", obj.Name, obj.ID)
+		}
+		return "", fmt.Errorf("too many objects found with name '%s'", name)
+	}
+
+	return matches[0].ID, nil
+}
+
+// verifyImportedObject ensures the object we're about to import is of the
+// kind the resource manages, in order to avoid confusing diffs on the next
+// plan.
+func verifyImportedObject(client bw.Client, id string, attrObject bw.ObjectType, attrType bw.ItemType) error {
+	obj, err := client.GetObject(bw.Object{ID: id, Object: attrObject, Type: attrType})
+	if err != nil {
+		return err
+	}
+
+	if obj.Object != attrObject {
+		return fmt.Errorf("object '%s' is of type '%s', expected '%s'", id, obj.Object, attrObject)
+	}
+
+	if obj.Type != attrType {
+		return fmt.Errorf("item '%s' is a %s, expected a %s", id, itemTypeName(obj.Type), itemTypeName(attrType))
+	}
+
+	if obj.DeletedDate != nil {
+		return fmt.Errorf("item '%s' is soft deleted", id)
+	}
+	return nil
+}
+
+func itemTypeName(itemType bw.ItemType) string {
+	switch itemType {
+	case bw.ItemTypeLogin:
+		return "login"
+	case bw.ItemTypeSecureNote:
+		return "secure note"
+	default:
+		return fmt.Sprintf("item of type %d", itemType)
+	}
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportItemResource(t *testing.T) {
+	testCases := []struct {
+		name          string
+		importID      string
+		commands      map[string]string
+		expectedID    string
+		expectedError string
+	}{
+		{
+			name:     "by id",
+			importID: "item-1",
+			commands: map[string]string{
+				"get item item-1": `{"id": "item-1", "object": "item", "type": 1}`,
+			},
+			expectedID: "item-1",
+		},
+		{
+			name:     "by id with wrong type",
+			importID: "item-1",
+			commands: map[string]string{
+				"get item item-1": `{"id": "item-1", "object": "item", "type": 2}`,
+			},
+			expectedError: "item 'item-1' is a secure note, expected a login",
+		},
+		{
+			name:     "by name",
+			importID: "name:Database",
+			commands: map[string]string{
+				"list items --search Database": `[{"id": "item-1", "name": "Database", "type": 1}, {"id": "item-2", "name": "Database Admin", "type": 1}, {"id": "item-3", "name": "Database", "type": 2}]`,
+				"get item item-1":              `{"id": "item-1", "object": "item", "type": 1}`,
+			},
+			expectedID: "item-1",
+		},
+		{
+			name:     "by name with duplicates",
+			importID: "name:Database",
+			commands: map[string]string{
+				"list items --search Database": `[{"id": "item-1", "name": "Database", "type": 1}, {"id": "item-2", "name": "Database", "type": 1}]`,
+			},
+			expectedError: "too many objects found with name 'Database'",
+		},
+		{
+			name:     "by folder",
+			importID: "folder:Production/Database",
+			commands: map[string]string{
+				"list folders --search Production":                 `[{"id": "folder-1", "name": "Production"}]`,
+				"list items --folderid folder-1 --search Database": `[{"id": "item-1", "name": "Database", "type": 1}]`,
+				"get item item-1":                                  `{"id": "item-1", "object": "item", "type": 1}`,
+			},
+			expectedID: "item-1",
+		},
+		{
+			name:     "by nested folder",
+			importID: "folder:Production/Databases/Main",
+			commands: map[string]string{
+				"list folders --search Production/Databases":   `[{"id": "folder-1", "name": "Production"}, {"id": "folder-2", "name": "Production/Databases"}]`,
+				"list items --folderid folder-2 --search Main": `[{"id": "item-1", "name": "Main", "type": 1}]`,
+				"get item item-1": `{"id": "item-1", "object": "item", "type": 1}`,
+			},
+			expectedID: "item-1",
+		},
+		{
+			name:          "by folder with invalid format",
+			importID:      "folder:Production/",
+			commands:      map[string]string{},
+			expectedError: "invalid ID specified, should be in the format folder:<folder_path>/<item_name>: 'folder:Production/'",
+		},
+		{
+			name:     "by collection",
+			importID: "collection:org-1/Engineering/Backend/Database",
+			commands: map[string]string{
+				"list org-collections --organizationid org-1 --search Engineering/Backend":  `[{"id": "coll-1", "name": "Engineering/Backend"}]`,
+				"list items --organizationid org-1 --collectionid coll-1 --search Database": `[{"id": "item-1", "name": "Database", "type": 1}]`,
+				"get item item-1": `{"id": "item-1", "object": "item", "type": 1}`,
+			},
+			expectedID: "item-1",
+		},
+		{
+			name:          "by collection with invalid format",
+			importID:      "collection:org-1/Database",
+			commands:      map[string]string{},
+			expectedError: "invalid ID specified, should be in the format collection:<organization_id>/<collection_path>/<item_name>: 'collection:org-1/Database'",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			removeMocks, _ := test_command.MockCommands(t, test.commands)
+			defer removeMocks(t)
+
+			r := resourceItemLogin()
+			d := r.TestResourceData()
+			d.SetId(test.importID)
+
+			res, err := r.Importer.StateContext(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+			if len(test.expectedError) > 0 {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			if assert.NoError(t, err) && assert.Len(t, res, 1) {
+				assert.Equal(t, test.expectedID, res[0].Id())
+				assert.Equal(t, string(bw.ObjectTypeItem), res[0].Get(attributeObject))
+				assert.Equal(t, int(bw.ItemTypeLogin), res[0].Get(attributeType))
+			}
+		})
+	}
+}
@@ -12,15 +12,15 @@ import (
 )
 
 func objectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.FromErr(objectOperation(ctx, d, meta.(bw.Client).CreateObject))
+	return diagFromErr(objectOperation(ctx, d, meta.(bw.Client).CreateObject))
 }
 
 func objectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if _, idProvided := d.GetOk(attributeID); !idProvided {
-		return diag.FromErr(objectSearch(d, meta))
+		return diagFromErr(objectSearch(d, meta))
 	}
 
-	return diag.FromErr(objectOperation(ctx, d, func(secret bw.Object) (*bw.Object, error) {
+	return diagFromErr(objectOperation(ctx, d, func(secret bw.Object) (*bw.Object, error) {
 		obj, err := meta.(bw.Client).GetObject(secret)
 		if obj != nil {
 			// If the object exists but is marked as soft deleted, we return an error, because relying
@@ -54,13 +54,9 @@ func objectSearch(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// If the object is an item, also filter by type to avoid returning a login when a secure note is expected.
+	// Data sources that don't set an item type (e.g. 'bitwarden_export_item') match any type of item.
 	if bw.ObjectType(objType.(string)) == bw.ObjectTypeItem {
-		itemType, ok := d.GetOk(attributeType)
-		if !ok {
-			return fmt.Errorf("BUG: item type not set in the resource data")
-		}
-
-		objs = bw.FilterObjectsByType(objs, bw.ItemType(itemType.(int)))
+		objs = bw.FilterObjectsByType(objs, bw.ItemType(d.Get(attributeType).(int)))
 	}
 
 	if len(objs) == 0 {
@@ -126,15 +122,116 @@ func objectReadIgnoreMissing(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.Diagnostics{}
 	}
 
-	return diag.FromErr(err)
+	return diagFromErr(err)
 }
 
+const (
+	onConflictFail      = "fail"
+	onConflictOverwrite = "overwrite"
+)
+
+// errRevisionConflict is returned when an item was modified outside of
+// Terraform since it was last read.
+var errRevisionConflict = errors.New("item was modified since it was last read")
+
 func objectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.FromErr(objectOperation(ctx, d, meta.(bw.Client).EditObject))
+	return diagFromErr(objectOperation(ctx, d, func(secret bw.Object) (*bw.Object, error) {
+		if secret.Object != bw.ObjectTypeItem {
+			return meta.(bw.Client).EditObject(secret)
+		}
+
+		// Hold the lock of bitwarden_item_field resources, so that their edits
+		// aren't lost between reading and saving the item.
+		unlock := lockItemFields(secret.ID)
+		defer unlock()
+
+		// Only pass what identifies the item, as attributes of the object given
+		// to GetObject() are kept when missing from the response.
+		current, err := meta.(bw.Client).GetObject(bw.Object{ID: secret.ID, Object: secret.Object, Type: secret.Type})
+		if err != nil {
+			return nil, err
+		}
+
+		known, _ := d.GetChange(attributeRevisionDate)
+		if revision, ok := known.(string); ok && len(revision) > 0 && current.RevisionDate != nil && current.RevisionDate.Format(bw.DateLayout) != revision {
+			if !d.HasChange(attributeField) && onlyItemFieldsEdited(secret.ID, revision, current.RevisionDate.Format(bw.DateLayout)) {
+				log.Printf("[DEBUG] Item '%s' only had its fields edited by the provider since it was last read, keeping them\n", secret.ID)
+			} else if d.Get(attributeOnConflict).(string) != onConflictOverwrite {
+				return nil, fmt.Errorf("%w: revision date of '%s' is %s, expected %s", errRevisionConflict, secret.ID, current.RevisionDate.Format(bw.DateLayout), revision)
+			} else {
+				log.Printf("[WARN] Item '%s' was modified since it was last read, applying the planned changes on top of its latest version\n", secret.ID)
+			}
+			secret = mergeObjectChanges(d, *current, secret)
+		}
+
+		// Items can't be moved to an organization by editing them, as they
+		// need to be re-encrypted with the key of the organization.
+		if len(current.OrganizationID) == 0 && len(secret.OrganizationID) > 0 {
+			_, err = meta.(bw.Client).ShareObject(secret.ID, secret.OrganizationID, secret.CollectionIds)
+			if err != nil {
+				return nil, err
+			}
+		} else if len(current.OrganizationID) > 0 && d.HasChange(attributeCollectionIDs) {
+			// Nor can they be assigned to other collections.
+			_, err = meta.(bw.Client).EditItemCollections(secret.ID, secret.CollectionIds)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return meta.(bw.Client).EditObject(secret)
+	}))
+}
+
+// mergeObjectChanges applies the attributes changed by a plan on top of the
+// latest version of an item, keeping the changes made outside of Terraform to
+// the other attributes.
+func mergeObjectChanges(d *schema.ResourceData, current, planned bw.Object) bw.Object {
+	merged := current
+
+	if d.HasChange(attributeName) {
+		merged.Name = planned.Name
+	}
+	if d.HasChange(attributeFolderID) {
+		merged.FolderID = planned.FolderID
+	}
+	if d.HasChange(attributeFavorite) {
+		merged.Favorite = planned.Favorite
+	}
+	if d.HasChange(attributeNotes) {
+		merged.Notes = planned.Notes
+	}
+	if d.HasChange(attributeOrganizationID) {
+		merged.OrganizationID = planned.OrganizationID
+	}
+	if d.HasChange(attributeReprompt) {
+		merged.Reprompt = planned.Reprompt
+	}
+	if d.HasChange(attributeCollectionIDs) {
+		merged.CollectionIds = planned.CollectionIds
+	}
+	if d.HasChange(attributeField) {
+		merged.Fields = planned.Fields
+	}
+
+	if merged.Type == bw.ItemTypeLogin {
+		if d.HasChange(attributeLoginPassword) {
+			merged.Login.Password = planned.Login.Password
+		}
+		if d.HasChange(attributeLoginTotp) {
+			merged.Login.Totp = planned.Login.Totp
+		}
+		if d.HasChange(attributeLoginUsername) {
+			merged.Login.Username = planned.Login.Username
+		}
+		if d.HasChange(attributeLoginURIs) {
+			merged.Login.URIs = planned.Login.URIs
+		}
+	}
+	return merged
 }
 
 func objectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.FromErr(objectOperation(ctx, d, func(secret bw.Object) (*bw.Object, error) {
+	return diagFromErr(objectOperation(ctx, d, func(secret bw.Object) (*bw.Object, error) {
 		return nil, meta.(bw.Client).DeleteObject(secret)
 	}))
 }
@@ -313,10 +410,13 @@ func objectStructFromData(d *schema.ResourceData) bw.Object {
 			obj.Reprompt = 1
 		}
 
-		if vList, ok := d.Get(attributeCollectionIDs).([]interface{}); ok {
-			obj.CollectionIds = make([]string, len(vList))
-			for k, v := range vList {
-				obj.CollectionIds[k] = v.(string)
+		switch v := d.Get(attributeCollectionIDs).(type) {
+		case *schema.Set:
+			obj.CollectionIds = stringsFromSet(v)
+		case []interface{}:
+			obj.CollectionIds = make([]string, len(v))
+			for k, id := range v {
+				obj.CollectionIds[k] = id.(string)
 			}
 		}
 
@@ -347,6 +447,14 @@ func objectStructFromData(d *schema.ResourceData) bw.Object {
 	return obj
 }
 
+func stringsFromSet(vSet *schema.Set) []string {
+	values := make([]string, 0, vSet.Len())
+	for _, v := range vSet.List() {
+		values = append(values, v.(string))
+	}
+	return values
+}
+
 func objectFieldDataFromStruct(obj *bw.Object) []interface{} {
 	fields := make([]interface{}, len(obj.Fields))
 	for k, f := range obj.Fields {
@@ -448,51 +556,19 @@ func objectLoginURIsFromStruct(objUris []bw.LoginURI) []interface{} {
 }
 
 func intMatchToStr(match *bw.URIMatch) URIMatchStr {
-	if match == nil {
-		return URIMatchDefault
-	}
-
-	switch *match {
-	case bw.URIMatchBaseDomain:
-		return URIMatchBaseDomain
-	case bw.URIMatchHost:
-		return URIMatchHost
-	case bw.URIMatchStartWith:
-		return URIMatchStartWith
-	case bw.URIMatchExact:
-		return URIMatchExact
-	case bw.URIMatchRegExp:
-		return URIMatchRegExp
-	case bw.URIMatchNever:
-		return URIMatchNever
-	default:
+	name, ok := bw.URIMatchName(match)
+	if !ok {
 		log.Printf("unsupported integer value for URI match: '%d'. Falling back to default\n", *match)
-		return URIMatchDefault
 	}
+	return URIMatchStr(name)
 }
 
 func strMatchToInt(match string) *bw.URIMatch {
-	var v bw.URIMatch
-	switch match {
-	case string(URIMatchDefault):
-		return nil
-	case string(URIMatchBaseDomain):
-		v = bw.URIMatchBaseDomain
-	case string(URIMatchHost):
-		v = bw.URIMatchHost
-	case string(URIMatchStartWith):
-		v = bw.URIMatchStartWith
-	case string(URIMatchExact):
-		v = bw.URIMatchExact
-	case string(URIMatchRegExp):
-		v = bw.URIMatchRegExp
-	case string(URIMatchNever):
-		v = bw.URIMatchNever
-	default:
+	v, ok := bw.URIMatchFromName(match)
+	if !ok {
 		log.Printf("unsupported string value for URI match: '%s'. Falling back to default\n", match)
-		return nil
 	}
-	return &v
+	return v
 }
 
 type URIMatchStr string
@@ -506,3 +582,5 @@ const (
 	URIMatchRegExp     URIMatchStr = "regexp"
 	URIMatchNever      URIMatchStr = "never"
 )
+
+var validURIMatchStr = bw.URIMatchNames()
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+const testItemUpdatedOutsideOfTerraform = `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "notes": "Edited in the web vault", "login": {"username": "admin", "password": "s3cr3t"}, "revisionDate": "2024-01-02T10:00:00.000Z"}`
+
+// testItemLoginState returns the state of a login item, with the given
+// attributes overriding the defaults.
+func testItemLoginState(attributes map[string]string) *terraform.InstanceState {
+	state := &terraform.InstanceState{
+		ID: "item-1",
+		Attributes: map[string]string{
+			attributeID:            "item-1",
+			attributeObject:        string(bw.ObjectTypeItem),
+			attributeType:          "1",
+			attributeName:          "Database",
+			attributeLoginUsername: "admin",
+			attributeLoginPassword: "s3cr3t",
+			attributeRevisionDate:  "2024-01-01T10:00:00.000Z",
+		},
+	}
+	for k, v := range attributes {
+		state.Attributes[k] = v
+	}
+	return state
+}
+
+// testItemLoginData returns the data of the login item of testItemLoginState,
+// planned to be updated to config.
+func testItemLoginData(t *testing.T, config map[string]interface{}) *schema.ResourceData {
+	r := resourceItemLogin()
+	state := testItemLoginState(nil)
+
+	diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil, nil, true)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return d
+}
+
+// testItemLoginDataForUpdate returns the data of a login item whose password
+// is changed by the plan.
+func testItemLoginDataForUpdate(t *testing.T, onConflict string) *schema.ResourceData {
+	config := map[string]interface{}{
+		attributeName:          "Database",
+		attributeLoginUsername: "admin",
+		attributeLoginPassword: "n3w-s3cr3t",
+	}
+	if len(onConflict) > 0 {
+		config[attributeOnConflict] = onConflict
+	}
+	return testItemLoginData(t, config)
+}
+
+func TestObjectUpdateFailsOnConflict(t *testing.T) {
+	removeMocks, executedCommands := test_command.MockCommands(t, map[string]string{
+		"get item item-1": testItemUpdatedOutsideOfTerraform,
+	})
+	defer removeMocks(t)
+
+	d := testItemLoginDataForUpdate(t, "")
+
+	diags := objectUpdate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "Bitwarden item modified outside of Terraform", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "revision date of 'item-1' is 2024-01-02T10:00:00.000Z, expected 2024-01-01T10:00:00.000Z")
+	}
+	assert.Equal(t, []string{"get item item-1"}, executedCommands())
+}
+
+func TestObjectUpdateOverwritesOnConflict(t *testing.T) {
+	removeMocks, executedCommands := test_command.MockCommands(t, map[string]string{
+		"get item item-1":               testItemUpdatedOutsideOfTerraform,
+		"encode":                        "ZW5jb2RlZA==",
+		"edit item item-1 ZW5jb2RlZA==": `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "notes": "Edited in the web vault", "login": {"username": "admin", "password": "n3w-s3cr3t"}, "revisionDate": "2024-01-03T10:00:00.000Z"}`,
+		"sync":                          ``,
+	})
+	defer removeMocks(t)
+
+	d := testItemLoginDataForUpdate(t, onConflictOverwrite)
+
+	diags := objectUpdate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	// The planned password is applied on top of the notes edited in the web vault.
+	var pushed string
+	for _, command := range executedCommands() {
+		if strings.HasSuffix(command, ":/:encode") {
+			pushed = command
+		}
+	}
+	assert.Contains(t, pushed, `"password":"n3w-s3cr3t"`)
+	assert.Contains(t, pushed, `"notes":"Edited in the web vault"`)
+	assert.Equal(t, "2024-01-03T10:00:00.000Z", d.Get(attributeRevisionDate))
+}
+
+func TestObjectUpdateKeepsFieldsEditedByTheProvider(t *testing.T) {
+	removeMocks, executedCommands := test_command.MockCommands(t, map[string]string{
+		"get item item-1":               `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "login": {"username": "admin", "password": "s3cr3t"}, "fields": [{"name": "port", "value": "5432", "type": 0}], "revisionDate": "2024-01-02T10:00:00.000Z"}`,
+		"encode":                        "ZW5jb2RlZA==",
+		"edit item item-1 ZW5jb2RlZA==": `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "login": {"username": "admin", "password": "n3w-s3cr3t"}, "fields": [{"name": "port", "value": "5432", "type": 0}], "revisionDate": "2024-01-03T10:00:00.000Z"}`,
+		"sync":                          ``,
+	})
+	defer removeMocks(t)
+
+	// A bitwarden_item_field resource edited the item earlier in the apply.
+	before, after := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	recordItemFieldRevision("item-1", &before, &after)
+	defer itemFieldRevisions.Delete("item-1/2024-01-01T10:00:00.000Z")
+
+	d := testItemLoginDataForUpdate(t, "")
+
+	diags := objectUpdate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	var pushed string
+	for _, command := range executedCommands() {
+		if strings.HasSuffix(command, ":/:encode") {
+			pushed = command
+		}
+	}
+	assert.Contains(t, pushed, `"password":"n3w-s3cr3t"`)
+	assert.Contains(t, pushed, `"name":"port"`)
+}
+
+func TestObjectUpdateWithoutConflict(t *testing.T) {
+	removeMocks, executedCommands := test_command.MockCommands(t, map[string]string{
+		"get item item-1":               `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "revisionDate": "2024-01-01T10:00:00.000Z"}`,
+		"encode":                        "ZW5jb2RlZA==",
+		"edit item item-1 ZW5jb2RlZA==": `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "login": {"username": "admin", "password": "n3w-s3cr3t"}, "revisionDate": "2024-01-03T10:00:00.000Z"}`,
+		"sync":                          ``,
+	})
+	defer removeMocks(t)
+
+	d := testItemLoginDataForUpdate(t, "")
+
+	diags := objectUpdate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if assert.False(t, diags.HasError(), diags) {
+		assert.Contains(t, executedCommands(), "edit item item-1 ZW5jb2RlZA==")
+	}
+}
+
+func TestObjectUpdateSharesItemWithOrganization(t *testing.T) {
+	removeMocks, executedCommands := test_command.MockCommands(t, map[string]string{
+		"get item item-1":                 `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "revisionDate": "2024-01-01T10:00:00.000Z"}`,
+		"encode":                          "ZW5jb2RlZA==",
+		"share item-1 org-1 ZW5jb2RlZA==": `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "organizationId": "org-1", "collectionIds": ["col-1"], "revisionDate": "2024-01-02T10:00:00.000Z"}`,
+		"edit item item-1 ZW5jb2RlZA==":   `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "organizationId": "org-1", "collectionIds": ["col-1"], "revisionDate": "2024-01-03T10:00:00.000Z"}`,
+		"sync":                            ``,
+	})
+	defer removeMocks(t)
+
+	d := testItemLoginData(t, map[string]interface{}{
+		attributeName:           "Database",
+		attributeOrganizationID: "org-1",
+		attributeCollectionIDs:  []interface{}{"col-1"},
+	})
+
+	diags := objectUpdate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, []string{
+		"get item item-1",
+		`["col-1"]:/:encode`,
+		"share item-1 org-1 ZW5jb2RlZA==",
+		"sync",
+	}, executedCommands()[:4])
+	assert.Equal(t, "org-1", d.Get(attributeOrganizationID))
+}
+
+func TestCustomizeItemOwnershipDiff(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"list org-collections --organizationid org-1": `[{"id": "col-1", "object": "org-collection", "organizationId": "org-1"}]`,
+	})
+	defer removeMocks(t)
+	client := bw.NewClient("dummy", bw.DisableRetryBackoff())
+
+	testCases := map[string]struct {
+		state         map[string]string
+		config        map[string]interface{}
+		requiresNew   bool
+		expectedError string
+	}{
+		"personal to organization": {
+			config: map[string]interface{}{attributeOrganizationID: "org-1", attributeCollectionIDs: []interface{}{"col-1"}},
+		},
+		"personal to organization without collections": {
+			config:        map[string]interface{}{attributeOrganizationID: "org-1"},
+			expectedError: "'collection_ids' must be set to move an item to organization 'org-1'",
+		},
+		"collection of another organization": {
+			config:        map[string]interface{}{attributeOrganizationID: "org-1", attributeCollectionIDs: []interface{}{"col-2"}},
+			expectedError: "collection 'col-2' doesn't belong to organization 'org-1'",
+		},
+		"organization to personal": {
+			state:       map[string]string{attributeOrganizationID: "org-1", attributeCollectionIDs + ".#": "1", testCollectionIDsKey("col-1"): "col-1"},
+			config:      map[string]interface{}{},
+			requiresNew: true,
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := resourceItemLogin()
+			state := testItemLoginState(test.state)
+			test.config[attributeName] = "Database"
+
+			diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(test.config), r.CustomizeDiff, client, true)
+			if len(test.expectedError) > 0 {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.requiresNew, diff.RequiresNew())
+			}
+		})
+	}
+}
+
+func TestObjectUpdateAssignsCollections(t *testing.T) {
+	removeMocks, executedCommands := test_command.MockCommands(t, map[string]string{
+		"get item item-1": `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "organizationId": "org-1", "collectionIds": ["col-1"], "revisionDate": "2024-01-01T10:00:00.000Z"}`,
+		"encode":          "ZW5jb2RlZA==",
+		"edit item-collections item-1 ZW5jb2RlZA==": `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "organizationId": "org-1", "collectionIds": ["col-2"], "revisionDate": "2024-01-02T10:00:00.000Z"}`,
+		"edit item item-1 ZW5jb2RlZA==":             `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "organizationId": "org-1", "collectionIds": ["col-2"], "revisionDate": "2024-01-03T10:00:00.000Z"}`,
+		"sync":                                      ``,
+	})
+	defer removeMocks(t)
+
+	r := resourceItemLogin()
+	state := testItemLoginState(map[string]string{
+		attributeOrganizationID:       "org-1",
+		attributeCollectionIDs + ".#": "1",
+		testCollectionIDsKey("col-1"): "col-1",
+	})
+	diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		attributeName:           "Database",
+		attributeOrganizationID: "org-1",
+		attributeCollectionIDs:  []interface{}{"col-2"},
+	}), nil, nil, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	diags := objectUpdate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Contains(t, executedCommands(), `["col-2"]:/:encode`)
+	assert.Contains(t, executedCommands(), "edit item-collections item-1 ZW5jb2RlZA==")
+	assert.Equal(t, []interface{}{"col-2"}, d.Get(attributeCollectionIDs).(*schema.Set).List())
+}
+
+// testCollectionIDsKey returns the key of a collection in the flatmap of the
+// 'collection_ids' set.
+func testCollectionIDsKey(collectionID string) string {
+	return fmt.Sprintf("%s.%d", attributeCollectionIDs, schema.HashSchema(&schema.Schema{Type: schema.TypeString})(collectionID))
+}
+
+func TestURIMatchStrMatchesBitwardenNames(t *testing.T) {
+	assert.Equal(t, []string{
+		string(URIMatchDefault),
+		string(URIMatchBaseDomain),
+		string(URIMatchHost),
+		string(URIMatchStartWith),
+		string(URIMatchExact),
+		string(URIMatchRegExp),
+		string(URIMatchNever),
+	}, validURIMatchStr)
+
+	for _, name := range validURIMatchStr {
+		assert.Equal(t, URIMatchStr(name), intMatchToStr(strMatchToInt(name)))
+	}
+}
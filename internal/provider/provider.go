@@ -6,12 +6,55 @@ import (
 	"log"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/orgapi"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/totp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// bitwardenClients is the provider's meta. It embeds the Vault client, so
+// resources can keep using meta as a bw.Client.
+type bitwardenClients struct {
+	bw.Client
+
+	orgAPI         orgapi.Client
+	secretsManager bws.Client
+
+	// hashSecretsInState is set when secrets of login items must only be
+	// stored as salted hashes in the state.
+	hashSecretsInState bool
+}
+
+// orgAPIClientFromMeta returns the client of the Public API, which is only
+// available when an organization API key is configured.
+func orgAPIClientFromMeta(meta interface{}) (orgapi.Client, error) {
+	clients, ok := meta.(*bitwardenClients)
+	if !ok || clients.orgAPI == nil {
+		return nil, fmt.Errorf("the '%s' block of the provider must be configured to manage organizations", attributeOrganizationAPIKey)
+	}
+	return clients.orgAPI, nil
+}
+
+// secretsManagerClientFromMeta returns the client of Secrets Manager, which is
+// only available when a machine account access token is configured.
+func secretsManagerClientFromMeta(meta interface{}) (bws.Client, error) {
+	clients, ok := meta.(*bitwardenClients)
+	if !ok || clients.secretsManager == nil {
+		return nil, fmt.Errorf("the '%s' attribute of the provider must be configured to manage Secrets Manager objects", attributeAccessToken)
+	}
+	return clients.secretsManager, nil
+}
+
 type LoginMethod int
 
 const (
@@ -37,7 +80,7 @@ func New(version string) func() *schema.Provider {
 					Type:          schema.TypeString,
 					Description:   descriptionMasterPassword,
 					ConflictsWith: []string{attributeSessionKey},
-					AtLeastOneOf:  []string{attributeSessionKey},
+					AtLeastOneOf:  []string{attributeSessionKey, attributeAccessToken, attributeOrganizationAPIKey},
 					Optional:      true,
 					DefaultFunc:   schema.EnvDefaultFunc("BW_PASSWORD", nil),
 				},
@@ -45,7 +88,7 @@ func New(version string) func() *schema.Provider {
 					Type:          schema.TypeString,
 					Description:   descriptionSessionKey,
 					ConflictsWith: []string{attributeMasterPassword},
-					AtLeastOneOf:  []string{attributeMasterPassword},
+					AtLeastOneOf:  []string{attributeMasterPassword, attributeAccessToken, attributeOrganizationAPIKey},
 					Optional:      true,
 					DefaultFunc:   schema.EnvDefaultFunc("BW_SESSION", nil),
 				},
@@ -81,7 +124,7 @@ func New(version string) func() *schema.Provider {
 				attributeEmail: {
 					Type:        schema.TypeString,
 					Description: descriptionEmail,
-					Required:    true,
+					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("BW_EMAIL", nil),
 				},
 				attributeVaultPath: {
@@ -90,27 +133,212 @@ func New(version string) func() *schema.Provider {
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("BITWARDENCLI_APPDATA_DIR", ".bitwarden/"),
 				},
+				attributeVaultPathPerIdentity: {
+					Type:        schema.TypeBool,
+					Description: descriptionVaultPathPerIdentity,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("BW_VAULT_PATH_PER_IDENTITY", true),
+				},
 				attributeExtraCACertsPath: {
 					Type:        schema.TypeString,
 					Description: descriptionExtraCACertsPath,
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("NODE_EXTRA_CA_CERTS", nil),
 				},
+				attributeBWExecutable: {
+					Type:        schema.TypeString,
+					Description: descriptionBWExecutable,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("BW_EXECUTABLE", "bw"),
+				},
+				attributeBWVersion: {
+					Type:             schema.TypeString,
+					Description:      descriptionBWVersion,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("BW_VERSION_CONSTRAINT", nil),
+					ValidateDiagFunc: validateVersionConstraint,
+				},
+				attributeSessionCache: {
+					Type:        schema.TypeBool,
+					Description: descriptionSessionCache,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("BW_SESSION_CACHE", false),
+				},
+				attributeSessionCachePassphrase: {
+					Type:        schema.TypeString,
+					Description: descriptionSessionCachePassphrase,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("BW_SESSION_CACHE_PASSPHRASE", nil),
+				},
+				attributeSessionCacheTTL: {
+					Type:             schema.TypeString,
+					Description:      descriptionSessionCacheTTL,
+					Optional:         true,
+					Default:          "1h",
+					ValidateDiagFunc: validateDuration,
+				},
+				attributeOrganizationAPIKey: {
+					Type:        schema.TypeList,
+					Description: descriptionOrganizationAPIKey,
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							attributeClientID: {
+								Type:        schema.TypeString,
+								Description: descriptionOrganizationAPIKeyClientID,
+								Required:    true,
+							},
+							attributeClientSecret: {
+								Type:        schema.TypeString,
+								Description: descriptionOrganizationAPIKeyClientSecret,
+								Required:    true,
+								Sensitive:   true,
+							},
+						},
+					},
+				},
+				attributeAccessToken: {
+					Type:        schema.TypeString,
+					Description: descriptionAccessToken,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("BWS_ACCESS_TOKEN", nil),
+				},
+				attributeRegion: {
+					Type:             schema.TypeString,
+					Description:      descriptionRegion,
+					Optional:         true,
+					ConflictsWith:    []string{attributeEnvironment},
+					DefaultFunc:      schema.EnvDefaultFunc("BW_REGION", nil),
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{bw.RegionUS, bw.RegionEU}, false)),
+				},
+				attributeEnvironment: {
+					Type:          schema.TypeList,
+					Description:   descriptionEnvironment,
+					Optional:      true,
+					MaxItems:      1,
+					ConflictsWith: []string{attributeRegion},
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							attributeEnvironmentWebVault: {
+								Type:             schema.TypeString,
+								Description:      descriptionEnvironmentWebVault,
+								Optional:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+							},
+							attributeEnvironmentAPI: {
+								Type:             schema.TypeString,
+								Description:      descriptionEnvironmentAPI,
+								Optional:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+							},
+							attributeEnvironmentIdentity: {
+								Type:             schema.TypeString,
+								Description:      descriptionEnvironmentIdentity,
+								Optional:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+							},
+							attributeEnvironmentIcons: {
+								Type:             schema.TypeString,
+								Description:      descriptionEnvironmentIcons,
+								Optional:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+							},
+							attributeEnvironmentNotifications: {
+								Type:             schema.TypeString,
+								Description:      descriptionEnvironmentNotifications,
+								Optional:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+							},
+							attributeEnvironmentKeyConnector: {
+								Type:             schema.TypeString,
+								Description:      descriptionEnvironmentKeyConnector,
+								Optional:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+							},
+						},
+					},
+				},
+				attributeTwoFactorMethod: {
+					Type:             schema.TypeString,
+					Description:      descriptionTwoFactorMethod,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("BW_TWO_FACTOR_METHOD", nil),
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(twoFactorMethodNames(), false)),
+				},
+				attributeTwoFactorCode: {
+					Type:          schema.TypeString,
+					Description:   descriptionTwoFactorCode,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{attributeTwoFactorTOTPSecret},
+					DefaultFunc:   schema.EnvDefaultFunc("BW_TWO_FACTOR_CODE", nil),
+				},
+				attributeTwoFactorTOTPSecret: {
+					Type:          schema.TypeString,
+					Description:   descriptionTwoFactorTOTPSecret,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{attributeTwoFactorCode},
+					DefaultFunc:   schema.EnvDefaultFunc("BW_TWO_FACTOR_TOTP_SECRET", nil),
+				},
+				attributeRetryMaxAttempts: {
+					Type:             schema.TypeInt,
+					Description:      descriptionRetryMaxAttempts,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				},
+				attributeRetryBaseDelay: {
+					Type:             schema.TypeString,
+					Description:      descriptionRetryBaseDelay,
+					Optional:         true,
+					Default:          "1s",
+					ValidateDiagFunc: validateDuration,
+				},
+				attributeRetryMaxDelay: {
+					Type:             schema.TypeString,
+					Description:      descriptionRetryMaxDelay,
+					Optional:         true,
+					Default:          "30s",
+					ValidateDiagFunc: validateDuration,
+				},
+				attributeStoreSecretsInState: {
+					Type:        schema.TypeBool,
+					Description: descriptionStoreSecretsInState,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("BW_STORE_SECRETS_IN_STATE", true),
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
 				"bitwarden_attachment":       dataSourceAttachment(),
+				"bitwarden_export_item":      dataSourceExportItem(),
 				"bitwarden_folder":           dataSourceFolder(),
 				"bitwarden_item_login":       dataSourceItemLogin(),
 				"bitwarden_item_secure_note": dataSourceItemSecureNote(),
+				"bitwarden_items_for_url":    dataSourceItemsForURL(),
 				"bitwarden_org_collection":   dataSourceOrgCollection(),
+				"bitwarden_org_events":       dataSourceOrgEvents(),
 				"bitwarden_organization":     dataSourceOrganization(),
+				"bitwarden_sm_secret":        dataSourceSMSecret(),
+				"bitwarden_status":           dataSourceStatus(),
+				"bitwarden_vault_report":     dataSourceVaultReport(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"bitwarden_attachment":       resourceAttachment(),
-				"bitwarden_folder":           resourceFolder(),
-				"bitwarden_item_login":       resourceItemLogin(),
-				"bitwarden_item_secure_note": resourceItemSecureNote(),
-				"bitwarden_org_collection":   resourceOrgCollection(),
+				"bitwarden_attachment":         resourceAttachment(),
+				"bitwarden_folder":             resourceFolder(),
+				"bitwarden_item_collections":   resourceItemCollections(),
+				"bitwarden_item_field":         resourceItemField(),
+				"bitwarden_item_login":         resourceItemLogin(),
+				"bitwarden_item_secure_note":   resourceItemSecureNote(),
+				"bitwarden_org_collection":     resourceOrgCollection(),
+				"bitwarden_org_policy":         resourceOrgPolicy(),
+				"bitwarden_sm_access_token":    resourceSMAccessToken(),
+				"bitwarden_sm_machine_account": resourceSMMachineAccount(),
+				"bitwarden_sm_project":         resourceSMProject(),
+				"bitwarden_sm_project_access":  resourceSMProjectAccess(),
+				"bitwarden_sm_secret":          resourceSMSecret(),
 			},
 		}
 
@@ -121,24 +349,91 @@ func New(version string) func() *schema.Provider {
 
 func providerConfigure(version string, _ *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		secretsManagerClient, err := newSecretsManagerClient(ctx, d, version)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 
-		bwClient, err := newBitwardenClient(ctx, d, version)
+		orgAPIClient, err := newOrgAPIClient(ctx, d, version)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
-		sessionKey, hasSessionKey := d.GetOk(attributeSessionKey)
-		if hasSessionKey {
-			bwClient.SetSessionKey(sessionKey.(string))
+		// Machine accounts and organization API keys don't need a Vault: there
+		// is none to log in when neither a master password nor a session key
+		// is provided.
+		var bwClient bw.Client = unconfiguredClient{}
+		_, hasMasterPassword := d.GetOk(attributeMasterPassword)
+		_, hasSessionKey := d.GetOk(attributeSessionKey)
+		if hasMasterPassword || hasSessionKey || (secretsManagerClient == nil && orgAPIClient == nil) {
+			var diags diag.Diagnostics
+			bwClient, diags = newLoggedInBitwardenClient(ctx, d, version)
+			if diags.HasError() {
+				return nil, diags
+			}
 		}
 
-		err = ensureLoggedIn(d, bwClient)
-		if err != nil {
-			return nil, diag.FromErr(err)
+		return &bitwardenClients{
+			Client:             bwClient,
+			orgAPI:             orgAPIClient,
+			secretsManager:     secretsManagerClient,
+			hashSecretsInState: !d.Get(attributeStoreSecretsInState).(bool),
+		}, nil
+	}
+}
+
+// newLoggedInBitwardenClient returns a client of the Vault, after logging in
+// and unlocking it if needed.
+func newLoggedInBitwardenClient(ctx context.Context, d *schema.ResourceData, version string) (bw.Client, diag.Diagnostics) {
+	if _, hasEmail := d.GetOk(attributeEmail); !hasEmail {
+		return nil, diag.Errorf("'%s' is required to log in to the Vault", attributeEmail)
+	}
+
+	bwClient, err := newBitwardenClient(ctx, d, version)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// 'bw serve' doesn't expose its version, so the constraint, which may
+	// come from the environment, only applies to the Bitwarden CLI.
+	_, hasAPIEndpoint := d.GetOk(attributeAPIEndpoint)
+	if constraint, hasConstraint := d.GetOk(attributeBWVersion); hasConstraint && !hasAPIEndpoint {
+		diags := checkCLIVersion(bwClient, constraint.(string))
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	sessionKey, hasSessionKey := d.GetOk(attributeSessionKey)
+	if hasSessionKey {
+		bwClient.SetSessionKey(sessionKey.(string))
+	}
+
+	cache, err := newSessionCacheFromData(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var cachedSessionKey string
+	if cache != nil {
+		cachedSessionKey = cache.Load()
+		if len(cachedSessionKey) > 0 {
+			bwClient.SetSessionKey(cachedSessionKey)
 		}
+	}
 
-		return bwClient, nil
+	err = ensureLoggedIn(d, bwClient)
+	if err != nil {
+		return nil, diagFromErr(err)
 	}
+
+	if cache != nil && len(bwClient.GetSessionKey()) > 0 && bwClient.GetSessionKey() != cachedSessionKey {
+		err = cache.Save(bwClient.GetSessionKey())
+		if err != nil {
+			log.Printf("[WARN] Unable to save session cache: %v\n", err)
+		}
+	}
+	return bwClient, nil
 }
 
 func ensureLoggedIn(d *schema.ResourceData, bwClient bw.Client) error {
@@ -186,7 +481,11 @@ func ensureLoggedIn(d *schema.ResourceData, bwClient bw.Client) error {
 		return bwClient.LoginWithAPIKey(masterPassword.(string), clientID.(string), clientSecret.(string))
 	case LoginMethodPassword:
 		email := d.Get(attributeEmail)
-		return bwClient.LoginWithPassword(email.(string), masterPassword.(string))
+		options, err := twoFactorLoginOptions(d)
+		if err != nil {
+			return err
+		}
+		return bwClient.LoginWithPassword(email.(string), masterPassword.(string), options...)
 	}
 
 	// Scenario 4: We need to login but don't have the information to do so.
@@ -221,57 +520,335 @@ func loginMethod(d *schema.ResourceData) LoginMethod {
 	return LoginMethodNone
 }
 
+var twoFactorMethods = map[string]bw.TwoFactorMethod{
+	"authenticator": bw.TwoFactorMethodAuthenticator,
+	"email":         bw.TwoFactorMethodEmail,
+	"yubikey":       bw.TwoFactorMethodYubiKey,
+}
+
+func twoFactorMethodNames() []string {
+	names := make([]string, 0, len(twoFactorMethods))
+	for name := range twoFactorMethods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// twoFactorLoginOptions returns the two-step login options of a password
+// login. When a TOTP secret is configured, the code is computed right before
+// logging in, so it doesn't expire in the meantime.
+func twoFactorLoginOptions(d *schema.ResourceData) ([]bw.LoginOption, error) {
+	code, hasCode := d.GetOk(attributeTwoFactorCode)
+	totpSecret, hasTOTPSecret := d.GetOk(attributeTwoFactorTOTPSecret)
+	if !hasCode && !hasTOTPSecret {
+		return nil, nil
+	}
+
+	method := bw.TwoFactorMethodAuthenticator
+	if name, hasMethod := d.GetOk(attributeTwoFactorMethod); hasMethod {
+		method = twoFactorMethods[name.(string)]
+	}
+
+	if hasTOTPSecret {
+		if method != bw.TwoFactorMethodAuthenticator {
+			return nil, fmt.Errorf("'%s' can only be used with the 'authenticator' two-step login method", attributeTwoFactorTOTPSecret)
+		}
+
+		generated, err := totp.GenerateCode(totpSecret.(string), time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute two-step login code from '%s': %w", attributeTwoFactorTOTPSecret, err)
+		}
+		code = generated
+	}
+
+	return []bw.LoginOption{bw.WithTwoFactor(method, code.(string))}, nil
+}
+
 func logoutIfIdentityChanged(d *schema.ResourceData, bwClient bw.Client, status *bw.Status) error {
 	email := d.Get(attributeEmail).(string)
-	serverURL := d.Get(attributeServer).(string)
+	env, err := environmentFromData(d)
+	if err != nil {
+		return err
+	}
+
+	// The URLs of the other services are compared with the ones recorded when
+	// the Vault was configured, except for 'bw serve' instances which are
+	// configured on their own.
+	envChanged := !status.VaultFromServer(env.Server)
+	_, hasAPIEndpoint := d.GetOk(attributeAPIEndpoint)
+	vaultPath := ""
+	if !hasAPIEndpoint {
+		vaultPath, err = vaultPathFromData(d)
+		if err != nil {
+			return err
+		}
+		envChanged = envChanged || loadEnvironmentRecord(vaultPath, env.Server) != env
+	}
 
-	if (status.Status == bw.StatusLocked || status.Status == bw.StatusUnlocked) && (!status.VaultOfUser(email) || !status.VaultFromServer(serverURL)) {
+	// The Bitwarden CLI refuses to change the environment of a Vault someone
+	// is logged in.
+	if (status.Status == bw.StatusLocked || status.Status == bw.StatusUnlocked) && (!status.VaultOfUser(email) || envChanged) {
 		status.Status = bw.StatusUnauthenticated
 
-		log.Printf("Logging out as the local Vault belongs to a different identity (vault: '%v' on  '%s', provider: '%v' on '%s')\n", status.UserEmail, status.ServerURL, email, status.ServerURL)
-		err := bwClient.Logout()
+		log.Printf("Logging out as the local Vault belongs to a different identity or environment (vault: '%v' on  '%s', provider: '%v' on '%s')\n", status.UserEmail, status.ServerURL, email, env.Server)
+		err = bwClient.Logout()
 		if err != nil {
 			return err
 		}
 	}
 
-	if !status.VaultFromServer(serverURL) {
-		err := bwClient.SetServer(serverURL)
+	if envChanged {
+		err = bwClient.SetServer(env)
 		if err != nil {
 			return err
 		}
+
+		if !hasAPIEndpoint {
+			return saveEnvironmentRecord(vaultPath, env)
+		}
 	}
 	return nil
 }
 
-func newBitwardenClient(ctx context.Context, d *schema.ResourceData, version string) (bw.Client, error) {
-	if endpoint, ok := d.GetOk(attributeAPIEndpoint); ok {
-		return bw.NewRestClient(ctx, endpoint.(string)), nil
+func newSessionCacheFromData(d *schema.ResourceData) (*sessionCache, error) {
+	if !d.Get(attributeSessionCache).(bool) {
+		return nil, nil
 	}
 
-	opts := []bw.Options{}
-	if vaultPath, exists := d.GetOk(attributeVaultPath); exists {
-		abs, err := filepath.Abs(vaultPath.(string))
+	// Session keys provided by the user or handled by a 'bw serve' instance
+	// don't need to be cached.
+	_, hasSessionKey := d.GetOk(attributeSessionKey)
+	_, hasAPIEndpoint := d.GetOk(attributeAPIEndpoint)
+	if hasSessionKey || hasAPIEndpoint {
+		return nil, nil
+	}
+
+	passphrase, hasPassphrase := d.GetOk(attributeSessionCachePassphrase)
+	if !hasPassphrase {
+		passphrase, hasPassphrase = d.GetOk(attributeMasterPassword)
+	}
+	if !hasPassphrase {
+		return nil, fmt.Errorf("'%s' requires either '%s' or '%s' to be set", attributeSessionCache, attributeSessionCachePassphrase, attributeMasterPassword)
+	}
+
+	vaultPath, err := vaultPathFromData(d)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := time.ParseDuration(d.Get(attributeSessionCacheTTL).(string))
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := environmentFromData(d)
+	if err != nil {
+		return nil, err
+	}
+	return newSessionCache(vaultPath, passphrase.(string), ttl, d.Get(attributeEmail).(string), env.Server), nil
+}
+
+func checkCLIVersion(bwClient bw.Client, constraint string) diag.Diagnostics {
+	constraints, err := version.NewConstraint(constraint)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rawVersion, err := bwClient.Version()
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to determine the version of the Bitwarden CLI",
+			Detail:   fmt.Sprintf("A version constraint ('%s') is configured, but the version of the Bitwarden CLI couldn't be retrieved: %v", constraint, err),
+		}}
+	}
+
+	cliVersion, err := version.NewVersion(rawVersion)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to parse the version of the Bitwarden CLI",
+			Detail:   fmt.Sprintf("The Bitwarden CLI returned '%s', which is not a valid version: %v", rawVersion, err),
+		}}
+	}
+
+	if !constraints.Check(cliVersion) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unsupported Bitwarden CLI version",
+			Detail:   fmt.Sprintf("The installed Bitwarden CLI is version '%s', which doesn't satisfy the constraint '%s' set with '%s'. Install a supported version, or point '%s' to another executable.", cliVersion, constraint, attributeBWVersion, attributeBWExecutable),
+		}}
+	}
+	return nil
+}
+
+func validateVersionConstraint(val interface{}, _ cty.Path) diag.Diagnostics {
+	_, err := version.NewConstraint(val.(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid version constraint: %w", err))
+	}
+	return diag.Diagnostics{}
+}
+
+func validateDuration(val interface{}, _ cty.Path) diag.Diagnostics {
+	_, err := time.ParseDuration(val.(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid duration: %w", err))
+	}
+	return diag.Diagnostics{}
+}
+
+func newRetryPolicy(d *schema.ResourceData, version string) (retry.Policy, error) {
+	policy := retry.DefaultPolicy()
+
+	if maxAttempts, ok := d.GetOk(attributeRetryMaxAttempts); ok {
+		policy.MaxAttempts = maxAttempts.(int)
+	} else if version == versionDev {
+		// During development, we limit the number of attempts to make failing
+		// operations fail faster.
+		policy.MaxAttempts = 3
+	}
+
+	baseDelay, err := time.ParseDuration(d.Get(attributeRetryBaseDelay).(string))
+	if err != nil {
+		return policy, err
+	}
+	policy.BaseDelay = baseDelay
+
+	maxDelay, err := time.ParseDuration(d.Get(attributeRetryMaxDelay).(string))
+	if err != nil {
+		return policy, err
+	}
+	policy.MaxDelay = maxDelay
+
+	// During development, we disable retry backoffs to make some operations faster.
+	policy.DisableBackoff = version == versionDev
+	return policy, nil
+}
+
+func newOrgAPIClient(ctx context.Context, d *schema.ResourceData, version string) (orgapi.Client, error) {
+	apiKeys := d.Get(attributeOrganizationAPIKey).([]interface{})
+	if len(apiKeys) == 0 || apiKeys[0] == nil {
+		return nil, nil
+	}
+	apiKey := apiKeys[0].(map[string]interface{})
+
+	policy, err := newRetryPolicy(d, version)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := environmentFromData(d)
+	if err != nil {
+		return nil, err
+	}
+	return orgapi.NewClient(ctx, env.APIURL(), env.IdentityURL(), apiKey[attributeClientID].(string), apiKey[attributeClientSecret].(string), orgapi.WithRetryPolicy(policy)), nil
+}
+
+func newSecretsManagerClient(ctx context.Context, d *schema.ResourceData, version string) (bws.Client, error) {
+	rawAccessToken, hasAccessToken := d.GetOk(attributeAccessToken)
+	if !hasAccessToken {
+		return nil, nil
+	}
+
+	accessToken, err := bws.ParseAccessToken(rawAccessToken.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := newRetryPolicy(d, version)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := environmentFromData(d)
+	if err != nil {
+		return nil, err
+	}
+	return bws.NewClient(ctx, env.APIURL(), env.IdentityURL(), accessToken, bws.WithRetryPolicy(policy)), nil
+}
+
+// environmentFromData returns the URLs of the Bitwarden deployment, either
+// from the region shortcut or from the server URL and the environment block.
+func environmentFromData(d *schema.ResourceData) (bw.Environment, error) {
+	env := bw.Environment{Server: d.Get(attributeServer).(string)}
+
+	if region, hasRegion := d.GetOk(attributeRegion); hasRegion {
+		regionServerURL, err := bw.RegionServerURL(region.(string))
 		if err != nil {
-			return nil, err
+			return env, err
 		}
-		opts = append(opts, bw.WithAppDataDir(abs))
+
+		// 'server' always has a value, so we can only detect conflicts once
+		// it differs from its default.
+		server := strings.TrimSuffix(env.Server, "/")
+		if server != bw.DefaultBitwardenServerURL && server != regionServerURL {
+			return env, fmt.Errorf("'%s' conflicts with '%s': region '%s' is served from '%s'", attributeRegion, attributeServer, region, regionServerURL)
+		}
+		env.Server = regionServerURL
+		return env, nil
+	}
+
+	environments := d.Get(attributeEnvironment).([]interface{})
+	if len(environments) == 0 || environments[0] == nil {
+		return env, nil
+	}
+	urls := environments[0].(map[string]interface{})
+
+	env.WebVault = urls[attributeEnvironmentWebVault].(string)
+	env.API = urls[attributeEnvironmentAPI].(string)
+	env.Identity = urls[attributeEnvironmentIdentity].(string)
+	env.Icons = urls[attributeEnvironmentIcons].(string)
+	env.Notifications = urls[attributeEnvironmentNotifications].(string)
+	env.KeyConnector = urls[attributeEnvironmentKeyConnector].(string)
+	return env, nil
+}
+
+func newBitwardenClient(ctx context.Context, d *schema.ResourceData, version string) (bw.Client, error) {
+	policy, err := newRetryPolicy(d, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint, ok := d.GetOk(attributeAPIEndpoint); ok {
+		return bw.NewRestClient(ctx, endpoint.(string), bw.WithRetryPolicy(policy)), nil
 	}
 
+	opts := []bw.Options{bw.WithRetryPolicy(policy)}
 	if extraCACertsPath, exists := d.GetOk(attributeExtraCACertsPath); exists {
 		opts = append(opts, bw.WithExtraCACertsPath(extraCACertsPath.(string)))
 	}
 
 	if version == versionDev {
-		// During development, we disable Vault synchronization and retry backoffs to make some
+		// During development, we disable Vault synchronization to make some
 		// operations faster.
 		opts = append(opts, bw.DisableSync())
-		opts = append(opts, bw.DisableRetryBackoff())
 	}
-	bwExecutable, err := exec.LookPath("bw")
+	bwExecutable, err := exec.LookPath(d.Get(attributeBWExecutable).(string))
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the Bitwarden CLI: %w", err)
+	}
+
+	vaultPath, err := vaultPathFromData(d)
 	if err != nil {
 		return nil, err
 	}
 
+	if d.Get(attributeVaultPathPerIdentity).(bool) {
+		sharedVaultPath, err := filepath.Abs(d.Get(attributeVaultPath).(string))
+		if err != nil {
+			return nil, err
+		}
+
+		err = migrateSharedVault(sharedVaultPath, vaultPath, func(dir string) (*bw.Status, error) {
+			return bw.NewClient(bwExecutable, append(opts, bw.WithAppDataDir(dir))...).Status()
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	opts = append(opts, bw.WithAppDataDir(vaultPath))
+
 	return bw.NewClient(bwExecutable, opts...), nil
 }
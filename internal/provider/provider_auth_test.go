@@ -76,8 +76,9 @@ func sessionKeyTestProvider(email, sessionKey string) string {
 		server          = "%s"
 		email           = "%s"
 		session_key = "%s"
+%s
 	}
-`, testServerURL, email, sessionKey)
+`, testServerURL, email, sessionKey, tfConfigEnvironment())
 }
 
 func usernamePasswordTestProvider(email, password string) string {
@@ -86,8 +87,9 @@ func usernamePasswordTestProvider(email, password string) string {
 		master_password = "%s"
 		server          = "%s"
 		email           = "%s"
+%s
 	}
-`, password, testServerURL, email)
+`, password, testServerURL, email, tfConfigEnvironment())
 }
 
 func checkResourceId() resource.TestCheckFunc {
@@ -2,9 +2,14 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/totp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
@@ -191,3 +196,243 @@ func TestProviderReturnUnhandledError(t *testing.T) {
 		"status",
 	}, commandsExecuted())
 }
+
+func TestProviderFailsOnUnsupportedCLIVersion(t *testing.T) {
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{
+		"--version": `2023.2.0`,
+	})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"server":                "http://127.0.0.1/",
+		"email":                 "test@laverse.net",
+		"session_key":           "abcd1234",
+		"bw_version_constraint": ">= 2024.1.0",
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+
+	if assert.True(t, diag.HasError()) {
+		assert.Equal(t, "Unsupported Bitwarden CLI version", diag[0].Summary)
+		assert.Contains(t, diag[0].Detail, "'2023.2.0'")
+	}
+	assert.Equal(t, []string{
+		"--version",
+	}, commandsExecuted())
+}
+
+func TestProviderIgnoresCLIVersionConstraintWithAPIEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status":
+			w.Write([]byte(`{"success": true, "data": {"template": {"serverURL": "http://127.0.0.1/", "userEmail": "test@laverse.net", "status": "unlocked"}}}`))
+		case "/sync":
+			w.Write([]byte(`{"success": true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	raw := map[string]interface{}{
+		"server":                "http://127.0.0.1/",
+		"email":                 "test@laverse.net",
+		"master_password":       "master-password-9",
+		"api_endpoint":          server.URL,
+		"bw_version_constraint": ">= 2024.1.0",
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	assert.False(t, diag.HasError(), diag)
+}
+
+func TestProviderFailsOnMissingExecutable(t *testing.T) {
+	raw := map[string]interface{}{
+		"server":        "http://127.0.0.1/",
+		"email":         "test@laverse.net",
+		"session_key":   "abcd1234",
+		"bw_executable": "/non-existent/bw",
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+
+	if assert.True(t, diag.HasError()) {
+		assert.Contains(t, diag[0].Summary, "unable to find the Bitwarden CLI")
+	}
+}
+
+func TestProviderLoginWithTwoFactorCode(t *testing.T) {
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{
+		"status": `{"serverURL": "http://127.0.0.1/", "status": "unauthenticated"}`,
+		"login test@laverse.net --raw --passwordenv BW_PASSWORD --method 1 --code 123456": `session-key1234`,
+	})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"server":            "http://127.0.0.1/",
+		"email":             "test@laverse.net",
+		"master_password":   "master-password-9",
+		"two_factor_method": "email",
+		"two_factor_code":   "123456",
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+
+	if !assert.False(t, diag.HasError()) {
+		t.Fatalf("unexpected error: %v", diag[0])
+	}
+
+	assert.Equal(t, []string{
+		"status",
+		"login test@laverse.net --raw --passwordenv BW_PASSWORD --method 1 --code 123456",
+	}, commandsExecuted())
+}
+
+func TestTwoFactorLoginOptionsFromTOTPSecret(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+
+	d := schema.TestResourceDataRaw(t, New(versionDev)().Schema, map[string]interface{}{
+		attributeTwoFactorTOTPSecret: secret,
+	})
+
+	before, err := totp.GenerateCode(secret, time.Now())
+	assert.NoError(t, err)
+	options, err := twoFactorLoginOptions(d)
+	assert.NoError(t, err)
+	after, err := totp.GenerateCode(secret, time.Now())
+	assert.NoError(t, err)
+
+	if assert.Len(t, options, 1) {
+		args := []string{}
+		options[0](&args)
+
+		// The code may roll over between the two computations.
+		if args[3] != before {
+			assert.Equal(t, []string{"--method", "0", "--code", after}, args)
+		} else {
+			assert.Equal(t, []string{"--method", "0", "--code", before}, args)
+		}
+	}
+}
+
+func TestTwoFactorLoginOptionsRejectsTOTPSecretWithOtherMethods(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, New(versionDev)().Schema, map[string]interface{}{
+		attributeTwoFactorMethod:     "yubikey",
+		attributeTwoFactorTOTPSecret: "JBSWY3DPEHPK3PXP",
+	})
+
+	_, err := twoFactorLoginOptions(d)
+	assert.EqualError(t, err, "'two_factor_totp_secret' can only be used with the 'authenticator' two-step login method")
+}
+
+func TestProviderConfiguresServerEnvironment(t *testing.T) {
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{
+		"status": `{"serverURL": null, "status": "unauthenticated"}`,
+		"config server https://bitwarden.example.com --api https://api.example.com --identity https://identity.example.com": ``,
+		"login test@laverse.net --raw --passwordenv BW_PASSWORD":                                                            `session-key1234`,
+	})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"server":          "https://bitwarden.example.com",
+		"email":           "test@laverse.net",
+		"master_password": "master-password-9",
+		"vault_path":      t.TempDir(),
+		"environment": []interface{}{map[string]interface{}{
+			"api":      "https://api.example.com",
+			"identity": "https://identity.example.com",
+		}},
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+
+	if !assert.False(t, diag.HasError()) {
+		t.Fatalf("unexpected error: %v", diag[0])
+	}
+
+	assert.Equal(t, []string{
+		"status",
+		"config server https://bitwarden.example.com --api https://api.example.com --identity https://identity.example.com",
+		"login test@laverse.net --raw --passwordenv BW_PASSWORD",
+	}, commandsExecuted())
+}
+
+func TestProviderReconfiguresServerEnvironmentOnChange(t *testing.T) {
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{
+		"status": `{"serverURL": "https://bitwarden.example.com", "userEmail": "test@laverse.net", "status": "locked"}`,
+		"logout": ``,
+		"config server https://bitwarden.example.com --api https://api.example.com": ``,
+		"login test@laverse.net --raw --passwordenv BW_PASSWORD":                    `session-key1234`,
+		"unlock --raw --passwordenv BW_PASSWORD":                                    `session-key1234`,
+	})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"server":          "https://bitwarden.example.com",
+		"email":           "test@laverse.net",
+		"master_password": "master-password-9",
+		"vault_path":      t.TempDir(),
+		"environment": []interface{}{map[string]interface{}{
+			"api": "https://api.example.com",
+		}},
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if !assert.False(t, diag.HasError()) {
+		t.Fatalf("unexpected error: %v", diag[0])
+	}
+
+	// The environment is only applied again once it changes.
+	diag = New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if !assert.False(t, diag.HasError()) {
+		t.Fatalf("unexpected error: %v", diag[0])
+	}
+
+	assert.Equal(t, []string{
+		"status",
+		"logout",
+		"config server https://bitwarden.example.com --api https://api.example.com",
+		"login test@laverse.net --raw --passwordenv BW_PASSWORD",
+		"status",
+		"unlock --raw --passwordenv BW_PASSWORD",
+	}, commandsExecuted())
+}
+
+func TestProviderConfiguresRegion(t *testing.T) {
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{
+		"status":                                 `{"serverURL": "https://vault.bitwarden.eu", "userEmail": "test@laverse.net", "status": "locked"}`,
+		"unlock --raw --passwordenv BW_PASSWORD": `session-key1234`,
+	})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"region":          "eu",
+		"email":           "test@laverse.net",
+		"master_password": "master-password-9",
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+
+	if !assert.False(t, diag.HasError()) {
+		t.Fatalf("unexpected error: %v", diag[0])
+	}
+
+	assert.Equal(t, []string{
+		"status",
+		"unlock --raw --passwordenv BW_PASSWORD",
+	}, commandsExecuted())
+}
+
+func TestProviderRejectsRegionConflictingWithServer(t *testing.T) {
+	raw := map[string]interface{}{
+		"region":          "eu",
+		"server":          "https://bitwarden.example.com",
+		"email":           "test@laverse.net",
+		"master_password": "master-password-9",
+	}
+
+	d := schema.TestResourceDataRaw(t, New(versionDev)().Schema, raw)
+
+	_, err := environmentFromData(d)
+	assert.EqualError(t, err, "'region' conflicts with 'server': region 'eu' is served from 'https://vault.bitwarden.eu'")
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/orgapi"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderConfiguresOrganizationAPIClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/identity/connect/token":
+			w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+		case "/api/public/members":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			w.Write([]byte(`{"object": "list", "data": [{"id": "member-1"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"status": `{"serverURL": "` + server.URL + `", "userEmail": "test@laverse.net", "status": "unlocked"}`,
+	})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"server":      server.URL,
+		"email":       "test@laverse.net",
+		"session_key": "abcd1234",
+		"organization_api_key": []interface{}{map[string]interface{}{
+			"client_id":     "organization.org-1",
+			"client_secret": "secret",
+		}},
+	}
+
+	p := New(versionDev)()
+	diag := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if !assert.False(t, diag.HasError()) {
+		t.Fatal(diag[0])
+	}
+
+	client, err := orgAPIClientFromMeta(p.Meta())
+	if assert.NoError(t, err) {
+		members, err := client.ListMembers()
+		assert.NoError(t, err)
+		assert.Len(t, members, 1)
+	}
+}
+
+func TestProviderConfiguresOrganizationAPIOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/identity/connect/token":
+			w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+		case "/api/public/members":
+			w.Write([]byte(`{"object": "list", "data": [{"id": "member-1"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"server": server.URL,
+		"organization_api_key": []interface{}{map[string]interface{}{
+			"client_id":     "organization.org-1",
+			"client_secret": "secret",
+		}},
+	}
+
+	p := New(versionDev)()
+	diag := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if !assert.False(t, diag.HasError()) {
+		t.Fatal(diag[0])
+	}
+	assert.Empty(t, commandsExecuted())
+
+	client, err := orgAPIClientFromMeta(p.Meta())
+	if assert.NoError(t, err) {
+		members, err := client.ListMembers()
+		assert.NoError(t, err)
+		assert.Len(t, members, 1)
+	}
+
+	_, err = p.Meta().(bw.Client).ListObjects("items")
+	assert.ErrorIs(t, err, errPasswordManagerNotConfigured)
+}
+
+func TestOrgAPIClientFromMetaWithoutAPIKey(t *testing.T) {
+	_, err := orgAPIClientFromMeta(&bitwardenClients{})
+
+	assert.EqualError(t, err, "the 'organization_api_key' block of the provider must be configured to manage organizations")
+}
+
+// newTestOrgAPIMeta returns a provider meta whose Public API client talks to
+// a local stand-in serving the given handlers, keyed by path.
+func newTestOrgAPIMeta(t *testing.T, handlers map[string]http.HandlerFunc) (*bitwardenClients, func()) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/connect/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+	})
+	for path, handler := range handlers {
+		mux.HandleFunc(path, handler)
+	}
+
+	server := httptest.NewServer(mux)
+	client := orgapi.NewClient(context.Background(), server.URL+"/api", server.URL+"/identity", "organization.org-1", "secret")
+	return &bitwardenClients{orgAPI: client}, server.Close
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	test_bws "github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws/test"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderConfiguresSecretsManagerClient(t *testing.T) {
+	server := test_bws.NewServer(t)
+	defer server.Close()
+
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"status": `{"serverURL": "` + server.URL + `", "userEmail": "test@laverse.net", "status": "unlocked"}`,
+	})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"server":       server.URL,
+		"email":        "test@laverse.net",
+		"session_key":  "abcd1234",
+		"access_token": server.AccessToken,
+	}
+
+	p := New(versionDev)()
+	diag := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if !assert.False(t, diag.HasError()) {
+		t.Fatal(diag[0])
+	}
+
+	client, err := secretsManagerClientFromMeta(p.Meta())
+	if assert.NoError(t, err) {
+		orgID, err := client.OrganizationID()
+		assert.NoError(t, err)
+		assert.Equal(t, test_bws.OrganizationID, orgID)
+	}
+}
+
+func TestProviderConfiguresSecretsManagerOnly(t *testing.T) {
+	server := test_bws.NewServer(t)
+	defer server.Close()
+
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"server":       server.URL,
+		"access_token": server.AccessToken,
+	}
+
+	p := New(versionDev)()
+	diag := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if !assert.False(t, diag.HasError()) {
+		t.Fatal(diag[0])
+	}
+	assert.Empty(t, commandsExecuted())
+
+	client, err := secretsManagerClientFromMeta(p.Meta())
+	if assert.NoError(t, err) {
+		orgID, err := client.OrganizationID()
+		assert.NoError(t, err)
+		assert.Equal(t, test_bws.OrganizationID, orgID)
+	}
+
+	_, err = p.Meta().(bw.Client).ListObjects("items")
+	assert.ErrorIs(t, err, errPasswordManagerNotConfigured)
+}
+
+func TestProviderRejectsMalformedAccessToken(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"status": `{"serverURL": "http://127.0.0.1/", "userEmail": "test@laverse.net", "status": "unlocked"}`,
+	})
+	defer removeMocks(t)
+
+	raw := map[string]interface{}{
+		"server":       "http://127.0.0.1/",
+		"email":        "test@laverse.net",
+		"session_key":  "abcd1234",
+		"access_token": "0.client.secret",
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if assert.True(t, diag.HasError()) {
+		assert.Contains(t, diag[0].Summary, "invalid access token: missing encryption key")
+	}
+}
+
+func TestSecretsManagerClientFromMetaWithoutAccessToken(t *testing.T) {
+	_, err := secretsManagerClientFromMeta(&bitwardenClients{})
+
+	assert.EqualError(t, err, "the 'access_token' attribute of the provider must be configured to manage Secrets Manager objects")
+}
+
+// newTestSecretsManagerMeta returns a provider meta whose Secrets Manager
+// client talks to a local stand-in.
+func newTestSecretsManagerMeta(t *testing.T) (*bitwardenClients, *test_bws.Server) {
+	server := test_bws.NewServer(t)
+
+	accessToken, err := bws.ParseAccessToken(server.AccessToken)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	client := bws.NewClient(context.Background(), server.URL+"/api", server.URL+"/identity", accessToken)
+	return &bitwardenClients{secretsManager: client}, server
+}
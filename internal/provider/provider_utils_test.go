@@ -12,6 +12,7 @@ import (
 
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -26,6 +27,7 @@ const (
 
 // Generated resources used for testing
 var testServerURL string
+var testEnvironment bw.Environment
 var testOrganizationID string
 var testCollectionID string
 
@@ -54,6 +56,28 @@ func init() {
 		port = "8080"
 	}
 	testServerURL = fmt.Sprintf("http://%s:%s/", host, port)
+
+	// Deployments serving the API and identity services on their own URLs
+	// are tested through the environment block of the provider.
+	testEnvironment = bw.Environment{
+		Server:   testServerURL,
+		API:      os.Getenv("VAULTWARDEN_API_URL"),
+		Identity: os.Getenv("VAULTWARDEN_IDENTITY_URL"),
+	}
+}
+
+// newTestWebAPIClient returns a client of the test instance which fails fast
+// when the instance is unreachable, instead of retrying for minutes.
+func newTestWebAPIClient() webapi.Client {
+	return webapi.NewClient(
+		testServerURL,
+		webapi.WithServiceURLs(testEnvironment.API, testEnvironment.Identity),
+		webapi.WithRetryPolicy(retry.Policy{
+			MaxAttempts: 3,
+			BaseDelay:   100 * time.Millisecond,
+			MaxDelay:    time.Second,
+		}),
+	)
 }
 
 func ensureVaultwardenHasUser(t *testing.T) {
@@ -64,7 +88,7 @@ func ensureVaultwardenHasUser(t *testing.T) {
 		return
 	}
 
-	webapiClient := webapi.NewClient(testServerURL)
+	webapiClient := newTestWebAPIClient()
 
 	err := webapiClient.RegisterUser("test", testEmail, testPassword, kdfIterations)
 	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "user already exists") {
@@ -81,7 +105,7 @@ func ensureVaultwardenConfigured(t *testing.T) {
 		return
 	}
 
-	webapiClient := webapi.NewClient(testServerURL)
+	webapiClient := newTestWebAPIClient()
 
 	userAlreadyExists := false
 	err := webapiClient.RegisterUser("test", testEmail, testPassword, kdfIterations)
@@ -133,8 +157,25 @@ func tfConfigProvider() string {
 		master_password = "%s"
 		server          = "%s"
 		email           = "%s"
+%s
+	}
+`, testPassword, testServerURL, testEmail, tfConfigEnvironment())
+}
+
+// tfConfigEnvironment returns the environment block of the provider for test
+// instances serving services on their own URLs.
+func tfConfigEnvironment() string {
+	urls := ""
+	if len(testEnvironment.API) > 0 {
+		urls = urls + fmt.Sprintf("\t\t\tapi      = %q\n", testEnvironment.API)
+	}
+	if len(testEnvironment.Identity) > 0 {
+		urls = urls + fmt.Sprintf("\t\t\tidentity = %q\n", testEnvironment.Identity)
+	}
+	if len(urls) == 0 {
+		return ""
 	}
-`, testPassword, testServerURL, testEmail)
+	return fmt.Sprintf("\t\tenvironment {\n%s\t\t}", urls)
 }
 
 func getObjectID(n string, objectId *string) resource.TestCheckFunc {
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
@@ -56,7 +57,7 @@ func TestProviderAuthAPIMethodMissingClientSecretThrowsError(t *testing.T) {
 
 	if assert.True(t, diag.HasError()) {
 		assert.Equal(t, "Missing required argument", diag[0].Summary)
-		assert.Regexp(t, regexp.MustCompile("all of `client_id,client_secret,master_password` must be specified|one of `master_password,session_key` must be specified"), diag[0].Detail)
+		assert.Regexp(t, regexp.MustCompile("all of `client_id,client_secret,master_password` must be specified|one of `access_token,master_password,organization_api_key,session_key` must be specified"), diag[0].Detail)
 	}
 }
 
@@ -72,7 +73,7 @@ func TestProviderAuthAPIMethodMissingMasterPasswordThrowsError(t *testing.T) {
 
 	if assert.True(t, diag.HasError()) {
 		assert.Equal(t, "Missing required argument", diag[0].Summary)
-		assert.Regexp(t, regexp.MustCompile("all of `client_id,client_secret,master_password` must be specified|one of `master_password,session_key` must be specified"), diag[0].Detail)
+		assert.Regexp(t, regexp.MustCompile("all of `client_id,client_secret,master_password` must be specified|one of `access_token,master_password,organization_api_key,session_key` must be specified"), diag[0].Detail)
 	}
 }
 
@@ -86,7 +87,7 @@ func TestProviderAuthPasswordMethodMissingMasterPasswordThrowsError(t *testing.T
 
 	if assert.True(t, diag.HasError()) {
 		assert.Equal(t, "Missing required argument", diag[0].Summary)
-		assert.Regexp(t, "\"(master_password|session_key)\": one of `master_password,session_key` must be specified", diag[0].Detail)
+		assert.Regexp(t, "\"(master_password|session_key)\": one of `access_token,master_password,organization_api_key,session_key` must be specified", diag[0].Detail)
 	}
 }
 
@@ -108,12 +109,34 @@ func TestProviderAuthAllMethodsMissingEmailThrowsError(t *testing.T) {
 		"master_password": "master-password-9",
 	}
 
-	diag := New(versionDev)().Validate(terraform.NewResourceConfigRaw(raw))
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
 
 	if assert.True(t, diag.HasError()) {
-		assert.Equal(t, "Missing required argument", diag[0].Summary)
-		assert.Equal(t, "The argument \"email\" is required, but no definition was found.", diag[0].Detail)
+		assert.Equal(t, "'email' is required to log in to the Vault", diag[0].Summary)
+	}
+}
+
+func TestProviderAuthAccessTokenOnlyValid(t *testing.T) {
+	raw := map[string]interface{}{
+		"access_token": "0.client-id.client-secret:a2V5",
+	}
+
+	diag := New(versionDev)().Validate(terraform.NewResourceConfigRaw(raw))
+
+	assert.False(t, diag.HasError())
+}
+
+func TestProviderAuthOrganizationAPIKeyOnlyValid(t *testing.T) {
+	raw := map[string]interface{}{
+		"organization_api_key": []interface{}{map[string]interface{}{
+			"client_id":     "organization.org-1",
+			"client_secret": "secret",
+		}},
 	}
+
+	diag := New(versionDev)().Validate(terraform.NewResourceConfigRaw(raw))
+
+	assert.False(t, diag.HasError())
 }
 
 func TestProviderAuthAllMethodsMissingServerNoError(t *testing.T) {
@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -25,8 +27,18 @@ func createResource(attrObject bw.ObjectType, attrType bw.ItemType) schema.Creat
 func importItemResource(attrObject bw.ObjectType, attrType bw.ItemType) *schema.ResourceImporter {
 	return &schema.ResourceImporter{
 		StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-			d.SetId(d.Id())
-			err := d.Set(attributeObject, attrObject)
+			id, err := resolveImportID(meta.(bw.Client), d.Id(), attrType)
+			if err != nil {
+				return nil, err
+			}
+
+			err = verifyImportedObject(meta.(bw.Client), id, attrObject, attrType)
+			if err != nil {
+				return nil, err
+			}
+
+			d.SetId(id)
+			err = d.Set(attributeObject, attrObject)
 			if err != nil {
 				return nil, err
 			}
@@ -38,3 +50,50 @@ func importItemResource(attrObject bw.ObjectType, attrType bw.ItemType) *schema.
 		},
 	}
 }
+
+// customizeItemOwnershipDiff handles changes of the organization of items:
+//   - items can be moved from the personal Vault to an organization, but not
+//     out of an organization, so these items are replaced,
+//   - items of an organization must be assigned to collections of this
+//     organization, which is checked at plan time.
+func customizeItemOwnershipDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChanges(attributeOrganizationID, attributeCollectionIDs) {
+		return nil
+	}
+
+	oldOrganizationID, newOrganizationID := d.GetChange(attributeOrganizationID)
+	if len(d.Id()) > 0 && len(oldOrganizationID.(string)) > 0 && oldOrganizationID != newOrganizationID {
+		err := d.ForceNew(attributeOrganizationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown(attributeOrganizationID) || !d.NewValueKnown(attributeCollectionIDs) || len(newOrganizationID.(string)) == 0 {
+		return nil
+	}
+
+	collectionIDs := stringsFromSet(d.Get(attributeCollectionIDs).(*schema.Set))
+	if len(d.Id()) > 0 && len(oldOrganizationID.(string)) == 0 && len(collectionIDs) == 0 {
+		return fmt.Errorf("'%s' must be set to move an item to organization '%s'", attributeCollectionIDs, newOrganizationID)
+	}
+	if len(collectionIDs) == 0 {
+		return nil
+	}
+
+	client, ok := meta.(bw.Client)
+	if !ok {
+		return nil
+	}
+	collections, err := client.ListObjects(fmt.Sprintf("%ss", bw.ObjectTypeOrgCollection), bw.WithOrganizationID(newOrganizationID.(string)))
+	if err != nil {
+		return err
+	}
+
+	for _, collectionID := range collectionIDs {
+		if !slices.ContainsFunc(collections, func(collection bw.Object) bool { return collection.ID == collectionID }) {
+			return fmt.Errorf("collection '%s' doesn't belong to organization '%s'", collectionID, newOrganizationID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceItemCollections() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the collections an item of an organization is assigned to, for items defined elsewhere. Leave `collection_ids` unset on the `bitwarden_item_login` or `bitwarden_item_secure_note` resource of the item. Deleting this resource unassigns the item from all collections.",
+
+		CreateContext: resourceItemCollectionsUpdate,
+		ReadContext:   resourceItemCollectionsRead,
+		UpdateContext: resourceItemCollectionsUpdate,
+		DeleteContext: resourceItemCollectionsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				err := d.Set(attributeItemCollectionsItemID, d.Id())
+				if err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			attributeItemCollectionsItemID: {
+				Description: descriptionItemCollectionsItemID,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			attributeCollectionIDs: {
+				Description: descriptionItemCollectionsCollectionIDs,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Required:    true,
+			},
+		},
+	}
+}
+
+func resourceItemCollectionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	item, err := meta.(bw.Client).GetObject(bw.Object{Object: bw.ObjectTypeItem, ID: d.Get(attributeItemCollectionsItemID).(string)})
+	if errors.Is(err, bw.ErrObjectNotFound) || err == nil && item.DeletedDate != nil {
+		d.SetId("")
+		log.Print("[WARN] Item not found, removing collections from state")
+		return diag.Diagnostics{}
+	} else if err != nil {
+		return diagFromErr(err)
+	}
+
+	return diag.FromErr(d.Set(attributeCollectionIDs, item.CollectionIds))
+}
+
+func resourceItemCollectionsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	itemID := d.Get(attributeItemCollectionsItemID).(string)
+
+	item, err := meta.(bw.Client).EditItemCollections(itemID, stringsFromSet(d.Get(attributeCollectionIDs).(*schema.Set)))
+	if err != nil {
+		return diagFromErr(fmt.Errorf("unable to assign item '%s' to collections: %w", itemID, err))
+	}
+
+	d.SetId(itemID)
+	return diag.FromErr(d.Set(attributeCollectionIDs, item.CollectionIds))
+}
+
+func resourceItemCollectionsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, err := meta.(bw.Client).EditItemCollections(d.Get(attributeItemCollectionsItemID).(string), []string{})
+	if errors.Is(err, bw.ErrObjectNotFound) {
+		return diag.Diagnostics{}
+	}
+	return diagFromErr(err)
+}
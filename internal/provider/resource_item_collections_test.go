@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceItemCollections(t *testing.T) {
+	removeMocks, executedCommands := test_command.MockCommands(t, map[string]string{
+		"encode": "ZW5jb2RlZA==",
+		"edit item-collections item-1 ZW5jb2RlZA==": `{"id": "item-1", "object": "item", "type": 1, "organizationId": "org-1", "collectionIds": ["col-1", "col-2"]}`,
+		"get item item-1": `{"id": "item-1", "object": "item", "type": 1, "organizationId": "org-1", "collectionIds": ["col-1"]}`,
+		"sync":            ``,
+	})
+	defer removeMocks(t)
+	client := bw.NewClient("dummy", bw.DisableRetryBackoff())
+
+	d := resourceItemCollections().TestResourceData()
+	d.Set(attributeItemCollectionsItemID, "item-1")
+	d.Set(attributeCollectionIDs, []interface{}{"col-1", "col-2"})
+
+	diags := resourceItemCollectionsUpdate(context.Background(), d, client)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, "item-1", d.Id())
+	assert.Contains(t, executedCommands(), `["col-1","col-2"]:/:encode`)
+
+	// Collections unassigned outside of Terraform are detected.
+	diags = resourceItemCollectionsRead(context.Background(), d, client)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, []string{"col-1"}, stringsFromSet(d.Get(attributeCollectionIDs).(*schema.Set)))
+
+	diags = resourceItemCollectionsDelete(context.Background(), d, client)
+	assert.False(t, diags.HasError(), diags)
+	assert.Contains(t, executedCommands(), `[]:/:encode`)
+}
+
+func TestResourceItemCollectionsOfPersonalItem(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"encode": "ZW5jb2RlZA==",
+		"edit item-collections item-1 ZW5jb2RlZA== @error": "Item does not belong to an organization. Consider moving it first.",
+	})
+	defer removeMocks(t)
+
+	d := resourceItemCollections().TestResourceData()
+	d.Set(attributeItemCollectionsItemID, "item-1")
+	d.Set(attributeCollectionIDs, []interface{}{"col-1"})
+
+	diags := resourceItemCollectionsUpdate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "unable to assign item 'item-1' to collections")
+	}
+}
@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// itemFieldMutexes serializes changes to the fields of an item, one mutex per
+// item. Fields are edited by replacing the whole item, so resources managing
+// sibling fields would otherwise overwrite each other.
+var itemFieldMutexes sync.Map
+
+func lockItemFields(itemID string) func() {
+	mutex, _ := itemFieldMutexes.LoadOrStore(itemID, &sync.Mutex{})
+	mutex.(*sync.Mutex).Lock()
+	return mutex.(*sync.Mutex).Unlock
+}
+
+// itemFieldRevisions maps the revision dates of items before their fields
+// were edited to the revision dates after, keyed by '<item_id>/<revision>'.
+// Resources managing the rest of an item tell these edits apart from changes
+// made outside of Terraform with it.
+var itemFieldRevisions sync.Map
+
+func recordItemFieldRevision(itemID string, before, after *time.Time) {
+	if before == nil || after == nil || before.Equal(*after) {
+		return
+	}
+	itemFieldRevisions.Store(itemID+"/"+before.Format(bw.DateLayout), after.Format(bw.DateLayout))
+}
+
+// onlyItemFieldsEdited tells whether an item went from the known revision to
+// the current one through field edits of the provider only.
+func onlyItemFieldsEdited(itemID, known, current string) bool {
+	for revision := known; revision != current; {
+		next, ok := itemFieldRevisions.Load(itemID + "/" + revision)
+		if !ok {
+			return false
+		}
+		revision = next.(string)
+	}
+	return true
+}
+
+func resourceItemField() *schema.Resource {
+	valueAttributes := []string{attributeFieldText, attributeFieldHidden, attributeFieldBoolean}
+
+	return &schema.Resource{
+		Description: "Manages a single custom field of an existing item, leaving the rest of the item untouched. If the item is also managed by a `bitwarden_item_login` or `bitwarden_item_secure_note` resource, ignore changes to its `field` attribute with `lifecycle { ignore_changes = [field] }`. Editing a field also changes the `revision_date` of the item: that resource doesn't take it for a conflict when both are updated in the same apply, and keeps the edited fields.",
+
+		CreateContext: resourceItemFieldCreate,
+		ReadContext:   resourceItemFieldRead,
+		UpdateContext: resourceItemFieldUpdate,
+		DeleteContext: resourceItemFieldDelete,
+		Importer:      importItemFieldResource(),
+
+		Schema: map[string]*schema.Schema{
+			attributeItemFieldItemID: {
+				Description: descriptionItemFieldItemID,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			attributeFieldName: {
+				Description: descriptionItemFieldName,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			attributeFieldText: {
+				Description:  descriptionFieldText,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: valueAttributes,
+			},
+			attributeFieldHidden: {
+				Description:  descriptionFieldHidden,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: valueAttributes,
+			},
+			attributeFieldBoolean: {
+				Description:  descriptionFieldBoolean,
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ExactlyOneOf: valueAttributes,
+			},
+		},
+	}
+}
+
+func importItemFieldResource() *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			itemID, name, found := strings.Cut(d.Id(), "/")
+			if !found || len(itemID) == 0 || len(name) == 0 {
+				return nil, fmt.Errorf("invalid ID specified, should be in the format <item_id>/<field_name>: '%s'", d.Id())
+			}
+			err := d.Set(attributeItemFieldItemID, itemID)
+			if err != nil {
+				return nil, err
+			}
+			err = d.Set(attributeFieldName, name)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+func resourceItemFieldCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get(attributeFieldName).(string)
+
+	err := editItemFields(d, meta, func(fields []bw.Field) ([]bw.Field, error) {
+		if _, existing := findItemField(fields, name); existing != nil {
+			return nil, fmt.Errorf("item '%s' already has a field named '%s', import it instead", d.Get(attributeItemFieldItemID), name)
+		}
+		return append(fields, itemFieldStructFromData(d)), nil
+	})
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get(attributeItemFieldItemID), name))
+	return diag.Diagnostics{}
+}
+
+func resourceItemFieldRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	item, err := meta.(bw.Client).GetObject(bw.Object{Object: bw.ObjectTypeItem, ID: d.Get(attributeItemFieldItemID).(string)})
+	if errors.Is(err, bw.ErrObjectNotFound) || err == nil && item.DeletedDate != nil {
+		d.SetId("")
+		log.Print("[WARN] Item not found, removing field from state")
+		return diag.Diagnostics{}
+	} else if err != nil {
+		return diagFromErr(err)
+	}
+
+	_, field := findItemField(item.Fields, d.Get(attributeFieldName).(string))
+	if field == nil {
+		d.SetId("")
+		log.Print("[WARN] Field not found, removing from state")
+		return diag.Diagnostics{}
+	}
+
+	return diag.FromErr(itemFieldDataFromStruct(d, field))
+}
+
+func resourceItemFieldUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get(attributeFieldName).(string)
+
+	return diagFromErr(editItemFields(d, meta, func(fields []bw.Field) ([]bw.Field, error) {
+		i, existing := findItemField(fields, name)
+		if existing == nil {
+			return append(fields, itemFieldStructFromData(d)), nil
+		}
+		fields[i] = itemFieldStructFromData(d)
+		return fields, nil
+	}))
+}
+
+func resourceItemFieldDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get(attributeFieldName).(string)
+
+	err := editItemFields(d, meta, func(fields []bw.Field) ([]bw.Field, error) {
+		i, existing := findItemField(fields, name)
+		if existing == nil {
+			return fields, nil
+		}
+		return append(fields[:i], fields[i+1:]...), nil
+	})
+	if errors.Is(err, bw.ErrObjectNotFound) {
+		return diag.Diagnostics{}
+	}
+	return diagFromErr(err)
+}
+
+// editItemFields reads the item of the resource, lets update change its
+// fields and saves the item, while holding the lock of the item.
+func editItemFields(d *schema.ResourceData, meta interface{}, update func([]bw.Field) ([]bw.Field, error)) error {
+	itemID := d.Get(attributeItemFieldItemID).(string)
+
+	unlock := lockItemFields(itemID)
+	defer unlock()
+
+	item, err := meta.(bw.Client).GetObject(bw.Object{Object: bw.ObjectTypeItem, ID: itemID})
+	if err != nil {
+		return err
+	}
+
+	item.Fields, err = update(item.Fields)
+	if err != nil {
+		return err
+	}
+
+	edited, err := meta.(bw.Client).EditObject(*item)
+	if err != nil {
+		return err
+	}
+	recordItemFieldRevision(itemID, item.RevisionDate, edited.RevisionDate)
+	return nil
+}
+
+func findItemField(fields []bw.Field, name string) (int, *bw.Field) {
+	for i := range fields {
+		if fields[i].Name == name {
+			return i, &fields[i]
+		}
+	}
+	return -1, nil
+}
+
+func itemFieldStructFromData(d *schema.ResourceData) bw.Field {
+	field := bw.Field{Name: d.Get(attributeFieldName).(string)}
+
+	if v, ok := d.GetOk(attributeFieldText); ok {
+		field.Type = bw.FieldTypeText
+		field.Value = v.(string)
+	} else if v, ok := d.GetOk(attributeFieldHidden); ok {
+		field.Type = bw.FieldTypeHidden
+		field.Value = v.(string)
+	} else {
+		field.Type = bw.FieldTypeBoolean
+		field.Value = fmt.Sprintf("%t", d.Get(attributeFieldBoolean).(bool))
+	}
+	return field
+}
+
+func itemFieldDataFromStruct(d *schema.ResourceData, field *bw.Field) error {
+	values := map[string]interface{}{
+		attributeFieldText:    nil,
+		attributeFieldHidden:  nil,
+		attributeFieldBoolean: nil,
+	}
+
+	switch field.Type {
+	case bw.FieldTypeText:
+		values[attributeFieldText] = field.Value
+	case bw.FieldTypeHidden:
+		values[attributeFieldHidden] = field.Value
+	case bw.FieldTypeBoolean:
+		values[attributeFieldBoolean] = field.Value == "true"
+	default:
+		return fmt.Errorf("field '%s' is a linked field, which isn't supported", field.Name)
+	}
+
+	for attribute, value := range values {
+		err := d.Set(attribute, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
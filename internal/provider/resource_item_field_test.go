@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const testItemWithFields = `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "login": {"username": "admin", "password": "s3cr3t"}, "fields": [{"name": "db_port", "value": "5432", "type": 0}, {"name": "api_key", "value": "k3y", "type": 1}]}`
+
+func mockItemFieldCommands(t *testing.T) (func(t *testing.T), func() string) {
+	removeMocks, executedCommands := test_command.MockCommands(t, map[string]string{
+		"get item item-1":               testItemWithFields,
+		"encode":                        "ZW5jb2RlZA==",
+		"edit item item-1 ZW5jb2RlZA==": testItemWithFields,
+		"sync":                          ``,
+	})
+
+	// Returns the item pushed to Bitwarden, as given to 'bw encode'.
+	return removeMocks, func() string {
+		for _, command := range executedCommands() {
+			if pushed, found := strings.CutSuffix(command, ":/:encode"); found {
+				return pushed
+			}
+		}
+		return ""
+	}
+}
+
+func TestResourceItemFieldCreate(t *testing.T) {
+	removeMocks, pushedItem := mockItemFieldCommands(t)
+	defer removeMocks(t)
+
+	d := resourceItemField().TestResourceData()
+	d.Set(attributeItemFieldItemID, "item-1")
+	d.Set(attributeFieldName, "db_host")
+	d.Set(attributeFieldText, "db.example.com")
+
+	diags := resourceItemFieldCreate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, "item-1/db_host", d.Id())
+
+	// Other fields and attributes of the item are left untouched.
+	pushed := pushedItem()
+	assert.Contains(t, pushed, `"fields":[{"name":"db_port","value":"5432","type":0,"linkedId":null},{"name":"api_key","value":"k3y","type":1,"linkedId":null},{"name":"db_host","value":"db.example.com","type":0,"linkedId":null}]`)
+	assert.Contains(t, pushed, `"password":"s3cr3t"`)
+}
+
+func TestResourceItemFieldCreateExisting(t *testing.T) {
+	removeMocks, pushedItem := mockItemFieldCommands(t)
+	defer removeMocks(t)
+
+	d := resourceItemField().TestResourceData()
+	d.Set(attributeItemFieldItemID, "item-1")
+	d.Set(attributeFieldName, "db_port")
+	d.Set(attributeFieldText, "5433")
+
+	diags := resourceItemFieldCreate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "item 'item-1' already has a field named 'db_port', import it instead", diags[0].Summary)
+	}
+	assert.Empty(t, pushedItem())
+}
+
+func TestResourceItemFieldReadUpdateDelete(t *testing.T) {
+	removeMocks, pushedItem := mockItemFieldCommands(t)
+	defer removeMocks(t)
+
+	d := resourceItemField().TestResourceData()
+	d.SetId("item-1/api_key")
+	d.Set(attributeItemFieldItemID, "item-1")
+	d.Set(attributeFieldName, "api_key")
+
+	diags := resourceItemFieldRead(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, "k3y", d.Get(attributeFieldHidden))
+	assert.Equal(t, "", d.Get(attributeFieldText))
+
+	d.Set(attributeFieldHidden, "n3w-k3y")
+	diags = resourceItemFieldUpdate(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Contains(t, pushedItem(), `"fields":[{"name":"db_port","value":"5432","type":0,"linkedId":null},{"name":"api_key","value":"n3w-k3y","type":1,"linkedId":null}]`)
+}
+
+func TestResourceItemFieldDelete(t *testing.T) {
+	removeMocks, pushedItem := mockItemFieldCommands(t)
+	defer removeMocks(t)
+
+	d := resourceItemField().TestResourceData()
+	d.SetId("item-1/db_port")
+	d.Set(attributeItemFieldItemID, "item-1")
+	d.Set(attributeFieldName, "db_port")
+
+	diags := resourceItemFieldDelete(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Contains(t, pushedItem(), `"fields":[{"name":"api_key","value":"k3y","type":1,"linkedId":null}]`)
+}
+
+func TestResourceItemFieldMissingIsRemovedFromState(t *testing.T) {
+	removeMocks, _ := mockItemFieldCommands(t)
+	defer removeMocks(t)
+
+	d := resourceItemField().TestResourceData()
+	d.SetId("item-1/db_host")
+	d.Set(attributeItemFieldItemID, "item-1")
+	d.Set(attributeFieldName, "db_host")
+
+	diags := resourceItemFieldRead(context.Background(), d, bw.NewClient("dummy", bw.DisableRetryBackoff()))
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestImportItemFieldResource(t *testing.T) {
+	d := resourceItemField().TestResourceData()
+	d.SetId("item-1/db/host")
+
+	res, err := importItemFieldResource().StateContext(context.Background(), d, nil)
+	if assert.NoError(t, err) && assert.Len(t, res, 1) {
+		assert.Equal(t, "item-1", res[0].Get(attributeItemFieldItemID))
+		assert.Equal(t, "db/host", res[0].Get(attributeFieldName))
+	}
+
+	d.SetId("item-1")
+	_, err = importItemFieldResource().StateContext(context.Background(), d, nil)
+	assert.EqualError(t, err, "invalid ID specified, should be in the format <item_id>/<field_name>: 'item-1'")
+}
+
+func TestLockItemFields(t *testing.T) {
+	unlock := lockItemFields("item-1")
+
+	// Fields of other items can be edited concurrently.
+	lockItemFields("item-2")()
+
+	locked := make(chan struct{})
+	go func() {
+		defer lockItemFields("item-1")()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("fields of an item must not be edited concurrently")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	<-locked
+}
+
+func TestOnlyItemFieldsEdited(t *testing.T) {
+	first := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	second, third := first.Add(time.Hour), first.Add(2*time.Hour)
+	recordItemFieldRevision("item-3", &first, &second)
+	recordItemFieldRevision("item-3", &second, &third)
+	defer itemFieldRevisions.Delete("item-3/" + first.Format(bw.DateLayout))
+	defer itemFieldRevisions.Delete("item-3/" + second.Format(bw.DateLayout))
+
+	assert.True(t, onlyItemFieldsEdited("item-3", first.Format(bw.DateLayout), third.Format(bw.DateLayout)))
+	assert.True(t, onlyItemFieldsEdited("item-3", third.Format(bw.DateLayout), third.Format(bw.DateLayout)))
+	assert.False(t, onlyItemFieldsEdited("item-3", first.Format(bw.DateLayout), first.Add(3*time.Hour).Format(bw.DateLayout)))
+	assert.False(t, onlyItemFieldsEdited("item-4", first.Format(bw.DateLayout), second.Format(bw.DateLayout)))
+}
@@ -1,7 +1,13 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"log"
+
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/urimatch"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -13,11 +19,40 @@ func resourceItemLogin() *schema.Resource {
 
 	return &schema.Resource{
 		Description:   "Manages a login item.",
-		CreateContext: createResource(bw.ObjectTypeItem, bw.ItemTypeLogin),
-		ReadContext:   objectReadIgnoreMissing,
-		UpdateContext: objectUpdate,
+		CreateContext: withHashedSecrets(createResource(bw.ObjectTypeItem, bw.ItemTypeLogin)),
+		ReadContext:   withHashedSecrets(objectReadIgnoreMissing),
+		UpdateContext: withHashedSecrets(objectUpdate),
 		DeleteContext: objectDelete,
+		CustomizeDiff: customdiff.All(customizeItemOwnershipDiff, customizeLoginURIsDiff),
 		Importer:      importItemResource(bw.ObjectTypeItem, bw.ItemTypeLogin),
-		Schema:        dataSourceItemSecureNoteSchema,
+		Schema:        withHashedSecretsSchema(dataSourceItemSecureNoteSchema),
+	}
+}
+
+// customizeLoginURIsDiff rejects invalid regular expression URIs at plan time,
+// instead of letting them silently never match. Go uses the RE2 syntax, while
+// Bitwarden clients accept JavaScript regular expressions: patterns using Perl
+// syntax RE2 lacks, like lookarounds or backreferences, are only warned about,
+// as the provider ignores them in its data sources and functions.
+func customizeLoginURIsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	uris, _ := d.Get(attributeLoginURIs).([]interface{})
+	for k, v := range uris {
+		uri, ok := v.(map[string]interface{})
+		if !ok || uri[attributeLoginURIsMatch] != string(URIMatchRegExp) {
+			continue
+		}
+		if !d.NewValueKnown(fmt.Sprintf("%s.%d.%s", attributeLoginURIs, k, attributeLoginURIsValue)) {
+			continue
+		}
+
+		err := urimatch.ValidateRegexp(uri[attributeLoginURIsValue].(string))
+		if err == nil {
+			continue
+		}
+		if !urimatch.IsUnsupportedSyntax(err) {
+			return fmt.Errorf("%s.%d: %w", attributeLoginURIs, k, err)
+		}
+		log.Printf("[WARN] %s.%d: %v, the URI is ignored when matching URLs with the provider\n", attributeLoginURIs, k, err)
 	}
+	return nil
 }
@@ -1,12 +1,15 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
 
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -223,3 +226,26 @@ func checkItemLoginUriMatches(resourceName string) resource.TestCheckFunc {
 		),
 	)
 }
+
+func TestCustomizeLoginURIsDiff(t *testing.T) {
+	r := resourceItemLogin()
+	config := func(uri string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			attributeName: "Database",
+			attributeLoginURIs: []interface{}{
+				map[string]interface{}{attributeLoginURIsMatch: "exact", attributeLoginURIsValue: "https://(example.com"},
+				map[string]interface{}{attributeLoginURIsMatch: "regexp", attributeLoginURIsValue: uri},
+			},
+		})
+	}
+
+	_, err := schema.InternalMap(r.Schema).Diff(context.Background(), nil, config(`^https://[a-z]+\.example\.com/`), r.CustomizeDiff, nil, true)
+	assert.NoError(t, err)
+
+	_, err = schema.InternalMap(r.Schema).Diff(context.Background(), nil, config("https://(example.com"), r.CustomizeDiff, nil, true)
+	assert.ErrorContains(t, err, "uri.1: invalid regular expression 'https://(example.com'")
+
+	// Bitwarden clients accept JavaScript regular expressions RE2 doesn't support.
+	_, err = schema.InternalMap(r.Schema).Diff(context.Background(), nil, config(`^https://(?!admin\.)[a-z]+\.example\.com/`), r.CustomizeDiff, nil, true)
+	assert.NoError(t, err)
+}
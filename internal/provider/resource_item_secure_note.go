@@ -14,6 +14,7 @@ func resourceItemSecureNote() *schema.Resource {
 		ReadContext:   objectReadIgnoreMissing,
 		UpdateContext: objectUpdate,
 		DeleteContext: objectDelete,
+		CustomizeDiff: customizeItemOwnershipDiff,
 		Importer:      importItemResource(bw.ObjectTypeItem, bw.ItemTypeSecureNote),
 		Schema:        dataSourceItemSecureNoteSchema,
 	}
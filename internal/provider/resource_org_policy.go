@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/orgapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// orgPolicyField maps an attribute of a policy's typed block onto a key of
+// the policy's data in the Public API.
+type orgPolicyField struct {
+	attribute   string
+	key         string
+	valueType   schema.ValueType
+	description string
+	validate    schema.SchemaValidateFunc
+}
+
+type orgPolicyType struct {
+	code   int
+	fields []orgPolicyField
+}
+
+// orgPolicyTypes are the policies which can be managed, keyed by the value of
+// the 'type' attribute. Policies with settings expect a block named after
+// their type.
+var orgPolicyTypes = map[string]orgPolicyType{
+	"two_step_login": {code: 0},
+	"master_password": {code: 1, fields: []orgPolicyField{
+		{attribute: "min_complexity", key: "minComplexity", valueType: schema.TypeInt, description: "Minimum complexity score of the master password, from `0` (weak) to `4` (strong).", validate: validation.IntBetween(0, 4)},
+		{attribute: "min_length", key: "minLength", valueType: schema.TypeInt, description: "Minimum length of the master password.", validate: validation.Any(validation.IntInSlice([]int{0}), validation.IntBetween(12, 128))},
+		{attribute: "require_upper", key: "requireUpper", valueType: schema.TypeBool, description: "Require upper case characters."},
+		{attribute: "require_lower", key: "requireLower", valueType: schema.TypeBool, description: "Require lower case characters."},
+		{attribute: "require_numbers", key: "requireNumbers", valueType: schema.TypeBool, description: "Require numbers."},
+		{attribute: "require_special", key: "requireSpecial", valueType: schema.TypeBool, description: "Require special characters."},
+		{attribute: "enforce_on_login", key: "enforceOnLogin", valueType: schema.TypeBool, description: "Require existing members to update master passwords which don't meet the requirements when logging in."},
+	}},
+	"password_generator": {code: 2, fields: []orgPolicyField{
+		{attribute: "override_password_type", key: "overridePasswordType", valueType: schema.TypeString, description: "Force the type of generated passwords (`password` or `passphrase`).", validate: validation.StringInSlice([]string{"", "password", "passphrase"}, false)},
+		{attribute: "min_length", key: "minLength", valueType: schema.TypeInt, description: "Minimum length of generated passwords.", validate: validation.Any(validation.IntInSlice([]int{0}), validation.IntBetween(5, 128))},
+		{attribute: "use_upper", key: "useUpper", valueType: schema.TypeBool, description: "Include upper case characters in generated passwords."},
+		{attribute: "use_lower", key: "useLower", valueType: schema.TypeBool, description: "Include lower case characters in generated passwords."},
+		{attribute: "use_numbers", key: "useNumbers", valueType: schema.TypeBool, description: "Include numbers in generated passwords."},
+		{attribute: "use_special", key: "useSpecial", valueType: schema.TypeBool, description: "Include special characters in generated passwords."},
+		{attribute: "min_numbers", key: "minNumbers", valueType: schema.TypeInt, description: "Minimum number of numbers in generated passwords.", validate: validation.IntBetween(0, 9)},
+		{attribute: "min_special", key: "minSpecial", valueType: schema.TypeInt, description: "Minimum number of special characters in generated passwords.", validate: validation.IntBetween(0, 9)},
+		{attribute: "min_number_words", key: "minNumberWords", valueType: schema.TypeInt, description: "Minimum number of words in generated passphrases.", validate: validation.Any(validation.IntInSlice([]int{0}), validation.IntBetween(3, 20))},
+		{attribute: "capitalize", key: "capitalize", valueType: schema.TypeBool, description: "Capitalize the words of generated passphrases."},
+		{attribute: "include_number", key: "includeNumber", valueType: schema.TypeBool, description: "Include a number in generated passphrases."},
+	}},
+	"single_org":                    {code: 3},
+	"require_sso":                   {code: 4},
+	"personal_ownership":            {code: 5},
+	"disable_send":                  {code: 6},
+	"send_options":                  {code: 7, fields: []orgPolicyField{{attribute: "disable_hide_email", key: "disableHideEmail", valueType: schema.TypeBool, description: "Prevent members from hiding their email address from Send recipients."}}},
+	"disable_personal_vault_export": {code: 10},
+	"activate_autofill":             {code: 11},
+}
+
+func orgPolicyTypeNames() []string {
+	names := make([]string, 0, len(orgPolicyTypes))
+	for name := range orgPolicyTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func resourceOrgPolicy() *schema.Resource {
+	s := map[string]*schema.Schema{
+		attributeOrgPolicyType: {
+			Description:  descriptionOrgPolicyType,
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(orgPolicyTypeNames(), false),
+		},
+		attributeOrgPolicyEnabled: {
+			Description: descriptionOrgPolicyEnabled,
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+	}
+
+	for name, policyType := range orgPolicyTypes {
+		if len(policyType.fields) == 0 {
+			continue
+		}
+
+		fields := map[string]*schema.Schema{}
+		for _, field := range policyType.fields {
+			fields[field.attribute] = &schema.Schema{
+				Description:  field.description,
+				Type:         field.valueType,
+				Optional:     true,
+				ValidateFunc: field.validate,
+			}
+		}
+
+		s[name] = &schema.Schema{
+			Description: fmt.Sprintf("Settings of the `%s` policy.", name),
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: fields},
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Manages a policy of an organization, through the Bitwarden Public API. Requires the `organization_api_key` block to be configured on the provider.",
+
+		CreateContext: resourceOrgPolicyUpdate,
+		ReadContext:   resourceOrgPolicyRead,
+		UpdateContext: resourceOrgPolicyUpdate,
+		DeleteContext: resourceOrgPolicyDelete,
+		CustomizeDiff: resourceOrgPolicyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrgPolicyResource,
+		},
+
+		Schema: s,
+	}
+}
+
+// resourceOrgPolicyCustomizeDiff ensures only the block of the selected type
+// of policy is used.
+func resourceOrgPolicyCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	selected := d.Get(attributeOrgPolicyType).(string)
+
+	for name, policyType := range orgPolicyTypes {
+		if len(policyType.fields) == 0 || name == selected {
+			continue
+		}
+		if blocks := d.Get(name).([]interface{}); len(blocks) > 0 {
+			return fmt.Errorf("the '%s' block can only be used with policies of type '%s', not '%s'", name, name, selected)
+		}
+	}
+	return nil
+}
+
+func resourceOrgPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := orgAPIClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get(attributeOrgPolicyType).(string)
+	policy, err := client.GetPolicy(orgPolicyTypes[name].code)
+	if errors.Is(err, orgapi.ErrNotFound) {
+		d.SetId("")
+		log.Print("[WARN] Policy not found, removing from state")
+		return diag.Diagnostics{}
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(orgPolicyDataFromStruct(d, name, policy))
+}
+
+func resourceOrgPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := orgAPIClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get(attributeOrgPolicyType).(string)
+	policy, err := client.UpdatePolicy(orgPolicyStructFromData(d, name))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(name)
+	return diag.FromErr(orgPolicyDataFromStruct(d, name, policy))
+}
+
+// resourceOrgPolicyDelete disables the policy, as policies can't be deleted.
+func resourceOrgPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := orgAPIClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy := orgPolicyStructFromData(d, d.Get(attributeOrgPolicyType).(string))
+	policy.Enabled = false
+
+	_, err = client.UpdatePolicy(policy)
+	return diag.FromErr(err)
+}
+
+func importOrgPolicyResource(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, ok := orgPolicyTypes[d.Id()]; !ok {
+		return nil, fmt.Errorf("invalid ID specified, should be a type of policy (%v): '%s'", orgPolicyTypeNames(), d.Id())
+	}
+
+	err := d.Set(attributeOrgPolicyType, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func orgPolicyStructFromData(d *schema.ResourceData, name string) orgapi.Policy {
+	policyType := orgPolicyTypes[name]
+	policy := orgapi.Policy{
+		Type:    policyType.code,
+		Enabled: d.Get(attributeOrgPolicyEnabled).(bool),
+		Data:    map[string]interface{}{},
+	}
+
+	if len(policyType.fields) == 0 {
+		policy.Data = nil
+		return policy
+	}
+
+	blocks := d.Get(name).([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return policy
+	}
+
+	block := blocks[0].(map[string]interface{})
+	for _, field := range policyType.fields {
+		switch v := block[field.attribute].(type) {
+		case bool:
+			policy.Data[field.key] = v
+		case int:
+			if v != 0 {
+				policy.Data[field.key] = v
+			}
+		case string:
+			if len(v) > 0 {
+				policy.Data[field.key] = v
+			}
+		}
+	}
+	return policy
+}
+
+func orgPolicyDataFromStruct(d *schema.ResourceData, name string, policy *orgapi.Policy) error {
+	err := d.Set(attributeOrgPolicyEnabled, policy.Enabled)
+	if err != nil {
+		return err
+	}
+
+	policyType := orgPolicyTypes[name]
+	if len(policyType.fields) == 0 {
+		return nil
+	}
+
+	block := map[string]interface{}{}
+	hasSettings := false
+	for _, field := range policyType.fields {
+		switch field.valueType {
+		case schema.TypeBool:
+			v, _ := policy.Data[field.key].(bool)
+			block[field.attribute] = v
+			hasSettings = hasSettings || v
+		case schema.TypeInt:
+			v, _ := policy.Data[field.key].(float64)
+			block[field.attribute] = int(v)
+			hasSettings = hasSettings || v != 0
+		case schema.TypeString:
+			v, _ := policy.Data[field.key].(string)
+			block[field.attribute] = v
+			hasSettings = hasSettings || len(v) > 0
+		}
+	}
+
+	// Policies without any setting are read back without a block, unless
+	// there already was one, to match configurations which don't use one.
+	if !hasSettings && len(d.Get(name).([]interface{})) == 0 {
+		return d.Set(name, []interface{}{})
+	}
+	return d.Set(name, []interface{}{block})
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceOrgPolicyLifecycle(t *testing.T) {
+	stored := map[string]interface{}{}
+	meta, closeServer := newTestOrgAPIMeta(t, map[string]http.HandlerFunc{
+		"/api/public/policies/1": func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "PUT" {
+				body, _ := io.ReadAll(r.Body)
+				assert.NoError(t, json.Unmarshal(body, &stored))
+			}
+			stored["type"] = 1
+			out, _ := json.Marshal(stored)
+			w.Write(out)
+		},
+	})
+	defer closeServer()
+
+	r := resourceOrgPolicy()
+	d := r.TestResourceData()
+	d.Set(attributeOrgPolicyType, "master_password")
+	d.Set(attributeOrgPolicyEnabled, true)
+	d.Set("master_password", []interface{}{map[string]interface{}{
+		"min_length":     14,
+		"require_upper":  true,
+		"min_complexity": 0,
+	}})
+
+	diags := resourceOrgPolicyUpdate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, "master_password", d.Id())
+	assert.Equal(t, map[string]interface{}{"minLength": float64(14), "requireUpper": true, "requireLower": false, "requireNumbers": false, "requireSpecial": false, "enforceOnLogin": false}, stored["data"])
+
+	// Someone changes the policy from the admin console.
+	stored["data"].(map[string]interface{})["minLength"] = 8
+
+	diags = resourceOrgPolicyRead(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, 8, d.Get("master_password.0.min_length"))
+	assert.Equal(t, true, d.Get("master_password.0.require_upper"))
+
+	diags = resourceOrgPolicyDelete(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, false, stored["enabled"])
+}
+
+func TestResourceOrgPolicyWithoutSettings(t *testing.T) {
+	meta, closeServer := newTestOrgAPIMeta(t, map[string]http.HandlerFunc{
+		"/api/public/policies/3": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"type": 3, "enabled": true, "data": null}`))
+		},
+	})
+	defer closeServer()
+
+	d := resourceOrgPolicy().TestResourceData()
+	d.SetId("single_org")
+	d.Set(attributeOrgPolicyType, "single_org")
+
+	diags := resourceOrgPolicyRead(context.Background(), d, meta)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, true, d.Get(attributeOrgPolicyEnabled))
+}
+
+func TestResourceOrgPolicyRemovedFromStateWhenNotFound(t *testing.T) {
+	meta, closeServer := newTestOrgAPIMeta(t, map[string]http.HandlerFunc{
+		"/api/public/policies/0": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		},
+	})
+	defer closeServer()
+
+	d := resourceOrgPolicy().TestResourceData()
+	d.SetId("two_step_login")
+	d.Set(attributeOrgPolicyType, "two_step_login")
+
+	diags := resourceOrgPolicyRead(context.Background(), d, meta)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceOrgPolicyValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		raw           map[string]interface{}
+		expectedError string
+	}{
+		{
+			name: "block matching type",
+			raw: map[string]interface{}{
+				"type":         "send_options",
+				"send_options": []interface{}{map[string]interface{}{"disable_hide_email": true}},
+			},
+		},
+		{
+			name: "block of another type",
+			raw: map[string]interface{}{
+				"type":         "single_org",
+				"send_options": []interface{}{map[string]interface{}{"disable_hide_email": true}},
+			},
+			expectedError: "the 'send_options' block can only be used with policies of type 'send_options', not 'single_org'",
+		},
+		{
+			name: "value out of range",
+			raw: map[string]interface{}{
+				"type":            "master_password",
+				"master_password": []interface{}{map[string]interface{}{"min_complexity": 5}},
+			},
+			expectedError: "expected master_password.0.min_complexity to be in the range (0 - 4), got 5",
+		},
+		{
+			name:          "unknown type",
+			raw:           map[string]interface{}{"type": "unknown"},
+			expectedError: "expected type to be one of",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			r := resourceOrgPolicy()
+			config := terraform.NewResourceConfigRaw(test.raw)
+
+			diags := r.Validate(config)
+			if !diags.HasError() {
+				_, err := r.Diff(context.Background(), nil, config, nil)
+				if err != nil {
+					diags = append(diags, diagFromErr(err)...)
+				}
+			}
+
+			if len(test.expectedError) == 0 {
+				assert.False(t, diags.HasError(), diags)
+			} else if assert.True(t, diags.HasError()) {
+				assert.Contains(t, diags[0].Summary, test.expectedError)
+			}
+		})
+	}
+}
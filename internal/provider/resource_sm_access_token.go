@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSMAccessToken() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages an access token of a Secrets Manager machine account. The value of the token is only returned when it gets created: imported tokens don't have one. Requires the `access_token` attribute to be configured on the provider.",
+
+		CreateContext: resourceSMAccessTokenCreate,
+		ReadContext:   resourceSMAccessTokenRead,
+		DeleteContext: resourceSMAccessTokenDelete,
+		Importer:      importSMAccessTokenResource(),
+
+		Schema: map[string]*schema.Schema{
+			attributeSMMachineAccountID: {
+				Description: descriptionSMAccessTokenMachineAccount,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			attributeName: {
+				Description: descriptionSMAccessTokenName,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			attributeSMAccessTokenExpirationDate: {
+				Description:      descriptionSMAccessTokenExpirationDate,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				DiffSuppressFunc: suppressEquivalentRFC3339Diff,
+			},
+			attributeSMAccessTokenValue: {
+				Description: descriptionSMAccessTokenValue,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			attributeCreationDate: {
+				Description: descriptionSMCreationDate,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func importSMAccessTokenResource() *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			split := strings.Split(d.Id(), "/")
+			if len(split) != 2 {
+				return nil, fmt.Errorf("invalid ID specified, should be in the format <machine_account_id>/<access_token_id>: '%s'", d.Id())
+			}
+			d.SetId(split[1])
+			err := d.Set(attributeSMMachineAccountID, split[0])
+			if err != nil {
+				return nil, err
+			}
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+func resourceSMAccessTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	token := bws.MachineAccountToken{Name: d.Get(attributeName).(string)}
+	if expirationDate, ok := d.GetOk(attributeSMAccessTokenExpirationDate); ok {
+		expiry, err := time.Parse(time.RFC3339, expirationDate.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		token.ExpirationDate = &expiry
+	}
+
+	created, err := client.CreateAccessToken(d.Get(attributeSMMachineAccountID).(string), token)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set(attributeSMAccessTokenValue, created.Value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smAccessTokenDataFromStruct(d, created))
+}
+
+func resourceSMAccessTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tokens, err := client.ListAccessTokens(d.Get(attributeSMMachineAccountID).(string))
+	if errors.Is(err, bws.ErrNotFound) {
+		d.SetId("")
+		log.Print("[WARN] Machine account not found, removing access token from state")
+		return diag.Diagnostics{}
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, token := range tokens {
+		if token.ID == d.Id() {
+			return diag.FromErr(smAccessTokenDataFromStruct(d, &token))
+		}
+	}
+
+	d.SetId("")
+	log.Print("[WARN] Access token not found, removing from state")
+	return diag.Diagnostics{}
+}
+
+func resourceSMAccessTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.RevokeAccessToken(d.Get(attributeSMMachineAccountID).(string), d.Id())
+	if errors.Is(err, bws.ErrNotFound) {
+		return diag.Diagnostics{}
+	}
+	return diag.FromErr(err)
+}
+
+func smAccessTokenDataFromStruct(d *schema.ResourceData, token *bws.MachineAccountToken) error {
+	d.SetId(token.ID)
+
+	err := d.Set(attributeName, token.Name)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set(attributeCreationDate, formatSMDate(token.CreationDate))
+	if err != nil {
+		return err
+	}
+
+	expirationDate := ""
+	if token.ExpirationDate != nil {
+		expirationDate = token.ExpirationDate.UTC().Format(time.RFC3339)
+	}
+	return d.Set(attributeSMAccessTokenExpirationDate, expirationDate)
+}
+
+// suppressEquivalentRFC3339Diff ignores differences between two dates
+// representing the same instant, e.g. in different time zones.
+func suppressEquivalentRFC3339Diff(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceSMAccessTokenLifecycle(t *testing.T) {
+	meta, server := newTestSecretsManagerMeta(t)
+	defer server.Close()
+
+	account, err := meta.secretsManager.CreateMachineAccount(bws.MachineAccount{Name: "CI"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	d := resourceSMAccessToken().TestResourceData()
+	d.Set(attributeSMMachineAccountID, account.ID)
+	d.Set(attributeName, "GitHub Actions")
+	d.Set(attributeSMAccessTokenExpirationDate, "2100-01-01T02:00:00+02:00")
+
+	diags := resourceSMAccessTokenCreate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	value := d.Get(attributeSMAccessTokenValue).(string)
+	_, err = bws.ParseAccessToken(value)
+	assert.NoError(t, err)
+
+	diags = resourceSMAccessTokenRead(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, value, d.Get(attributeSMAccessTokenValue))
+	assert.Equal(t, "2100-01-01T00:00:00Z", d.Get(attributeSMAccessTokenExpirationDate))
+
+	diags = resourceSMAccessTokenDelete(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	diags = resourceSMAccessTokenRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestSuppressEquivalentRFC3339Diff(t *testing.T) {
+	assert.True(t, suppressEquivalentRFC3339Diff("", "2100-01-01T00:00:00Z", "2100-01-01T02:00:00+02:00", nil))
+	assert.False(t, suppressEquivalentRFC3339Diff("", "2100-01-01T00:00:00Z", "2100-01-01T00:00:00+02:00", nil))
+	assert.False(t, suppressEquivalentRFC3339Diff("", "", "2100-01-01T00:00:00Z", nil))
+}
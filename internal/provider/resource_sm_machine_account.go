@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSMMachineAccount() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Secrets Manager machine account. Requires the `access_token` attribute to be configured on the provider.",
+
+		CreateContext: resourceSMMachineAccountCreate,
+		ReadContext:   resourceSMMachineAccountRead,
+		UpdateContext: resourceSMMachineAccountUpdate,
+		DeleteContext: resourceSMMachineAccountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			attributeName: {
+				Description: descriptionSMMachineAccountName,
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			attributeOrganizationID: {
+				Description: descriptionSMOrganizationID,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeCreationDate: {
+				Description: descriptionSMCreationDate,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeRevisionDate: {
+				Description: descriptionSMRevisionDate,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSMMachineAccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	account, err := client.CreateMachineAccount(bws.MachineAccount{Name: d.Get(attributeName).(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smMachineAccountDataFromStruct(d, account))
+}
+
+func resourceSMMachineAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	account, err := client.GetMachineAccount(d.Id())
+	if errors.Is(err, bws.ErrNotFound) {
+		d.SetId("")
+		log.Print("[WARN] Machine account not found, removing from state")
+		return diag.Diagnostics{}
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smMachineAccountDataFromStruct(d, account))
+}
+
+func resourceSMMachineAccountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	account, err := client.UpdateMachineAccount(bws.MachineAccount{ID: d.Id(), Name: d.Get(attributeName).(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smMachineAccountDataFromStruct(d, account))
+}
+
+func resourceSMMachineAccountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteMachineAccount(d.Id())
+	if errors.Is(err, bws.ErrNotFound) {
+		return diag.Diagnostics{}
+	}
+	return diag.FromErr(err)
+}
+
+func smMachineAccountDataFromStruct(d *schema.ResourceData, account *bws.MachineAccount) error {
+	d.SetId(account.ID)
+
+	err := d.Set(attributeName, account.Name)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set(attributeOrganizationID, account.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set(attributeCreationDate, formatSMDate(account.CreationDate))
+	if err != nil {
+		return err
+	}
+
+	return d.Set(attributeRevisionDate, formatSMDate(account.RevisionDate))
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceSMMachineAccountLifecycle(t *testing.T) {
+	meta, server := newTestSecretsManagerMeta(t)
+	defer server.Close()
+
+	d := resourceSMMachineAccount().TestResourceData()
+	d.Set(attributeName, "CI")
+
+	diags := resourceSMMachineAccountCreate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, "org-1", d.Get(attributeOrganizationID))
+
+	d.Set(attributeName, "GitHub Actions")
+	diags = resourceSMMachineAccountUpdate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	account, err := meta.secretsManager.GetMachineAccount(d.Id())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "GitHub Actions", account.Name)
+	}
+
+	diags = resourceSMMachineAccountDelete(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	diags = resourceSMMachineAccountRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+}
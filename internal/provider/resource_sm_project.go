@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSMProject() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Secrets Manager project. Requires the `access_token` attribute to be configured on the provider.",
+
+		CreateContext: resourceSMProjectCreate,
+		ReadContext:   resourceSMProjectRead,
+		UpdateContext: resourceSMProjectUpdate,
+		DeleteContext: resourceSMProjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			attributeName: {
+				Description: descriptionSMProjectName,
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			attributeOrganizationID: {
+				Description: descriptionSMOrganizationID,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeCreationDate: {
+				Description: descriptionSMCreationDate,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			attributeRevisionDate: {
+				Description: descriptionSMRevisionDate,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSMProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	project, err := client.CreateProject(bws.Project{Name: d.Get(attributeName).(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smProjectDataFromStruct(d, project))
+}
+
+func resourceSMProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	project, err := client.GetProject(d.Id())
+	if errors.Is(err, bws.ErrNotFound) {
+		d.SetId("")
+		log.Print("[WARN] Project not found, removing from state")
+		return diag.Diagnostics{}
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smProjectDataFromStruct(d, project))
+}
+
+func resourceSMProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	project, err := client.UpdateProject(bws.Project{ID: d.Id(), Name: d.Get(attributeName).(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smProjectDataFromStruct(d, project))
+}
+
+func resourceSMProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteProject(d.Id())
+	if errors.Is(err, bws.ErrNotFound) {
+		return diag.Diagnostics{}
+	}
+	return diag.FromErr(err)
+}
+
+func smProjectDataFromStruct(d *schema.ResourceData, project *bws.Project) error {
+	d.SetId(project.ID)
+
+	err := d.Set(attributeName, project.Name)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set(attributeOrganizationID, project.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set(attributeCreationDate, formatSMDate(project.CreationDate))
+	if err != nil {
+		return err
+	}
+
+	return d.Set(attributeRevisionDate, formatSMDate(project.RevisionDate))
+}
+
+func formatSMDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(bw.DateLayout)
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	smPermissionRead      = "read"
+	smPermissionReadWrite = "read_write"
+)
+
+// smProjectAccessMutex serializes changes to access policies, as they are
+// replaced project-wide by the API and resources of a same project would
+// otherwise overwrite each other.
+var smProjectAccessMutex sync.Mutex
+
+func resourceSMProjectAccess() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the access of a Secrets Manager machine account to a project. Requires the `access_token` attribute to be configured on the provider.",
+
+		CreateContext: resourceSMProjectAccessCreate,
+		ReadContext:   resourceSMProjectAccessRead,
+		UpdateContext: resourceSMProjectAccessUpdate,
+		DeleteContext: resourceSMProjectAccessDelete,
+		Importer:      importSMProjectAccessResource(),
+
+		Schema: map[string]*schema.Schema{
+			attributeSMProjectID: {
+				Description: descriptionSMProjectAccessProjectID,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			attributeSMMachineAccountID: {
+				Description: descriptionSMProjectAccessMachineID,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			attributeSMPermission: {
+				Description:      descriptionSMProjectAccessPermission,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{smPermissionRead, smPermissionReadWrite}, false)),
+			},
+		},
+	}
+}
+
+func importSMProjectAccessResource() *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			split := strings.Split(d.Id(), "/")
+			if len(split) != 2 {
+				return nil, fmt.Errorf("invalid ID specified, should be in the format <project_id>/<machine_account_id>: '%s'", d.Id())
+			}
+			d.Set(attributeSMProjectID, split[0])
+			err := d.Set(attributeSMMachineAccountID, split[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+func resourceSMProjectAccessCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := resourceSMProjectAccessUpdate(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get(attributeSMProjectID), d.Get(attributeSMMachineAccountID)))
+	return diags
+}
+
+func resourceSMProjectAccessRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	access, err := client.ListProjectAccess(d.Get(attributeSMProjectID).(string))
+	if errors.Is(err, bws.ErrNotFound) {
+		d.SetId("")
+		log.Print("[WARN] Project not found, removing access from state")
+		return diag.Diagnostics{}
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	machineAccountID := d.Get(attributeSMMachineAccountID).(string)
+	for _, a := range access {
+		if a.MachineAccountID != machineAccountID {
+			continue
+		}
+
+		if a.Write {
+			return diag.FromErr(d.Set(attributeSMPermission, smPermissionReadWrite))
+		} else if a.Read {
+			return diag.FromErr(d.Set(attributeSMPermission, smPermissionRead))
+		}
+	}
+
+	d.SetId("")
+	log.Print("[WARN] Project access not found, removing from state")
+	return diag.Diagnostics{}
+}
+
+func resourceSMProjectAccessUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	permission := d.Get(attributeSMPermission).(string)
+	return diag.FromErr(updateSMProjectAccess(d, meta, &bws.ProjectAccess{
+		MachineAccountID: d.Get(attributeSMMachineAccountID).(string),
+		Read:             true,
+		Write:            permission == smPermissionReadWrite,
+	}))
+}
+
+func resourceSMProjectAccessDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := updateSMProjectAccess(d, meta, nil)
+	if errors.Is(err, bws.ErrNotFound) {
+		return diag.Diagnostics{}
+	}
+	return diag.FromErr(err)
+}
+
+// updateSMProjectAccess replaces the access of the resource's machine account
+// on the project with newAccess, or revokes it when newAccess is nil, leaving
+// other machine accounts untouched.
+func updateSMProjectAccess(d *schema.ResourceData, meta interface{}, newAccess *bws.ProjectAccess) error {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return err
+	}
+
+	smProjectAccessMutex.Lock()
+	defer smProjectAccessMutex.Unlock()
+
+	projectID := d.Get(attributeSMProjectID).(string)
+	machineAccountID := d.Get(attributeSMMachineAccountID).(string)
+
+	access, err := client.ListProjectAccess(projectID)
+	if err != nil {
+		return err
+	}
+
+	updated := make([]bws.ProjectAccess, 0, len(access)+1)
+	for _, a := range access {
+		if a.MachineAccountID != machineAccountID {
+			updated = append(updated, a)
+		}
+	}
+	if newAccess != nil {
+		updated = append(updated, *newAccess)
+	}
+
+	_, err = client.UpdateProjectAccess(projectID, updated)
+	return err
+}
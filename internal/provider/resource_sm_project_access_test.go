@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceSMProjectAccessLifecycle(t *testing.T) {
+	meta, server := newTestSecretsManagerMeta(t)
+	defer server.Close()
+
+	project, err := meta.secretsManager.CreateProject(bws.Project{Name: "Production"})
+	assert.NoError(t, err)
+	ci, err := meta.secretsManager.CreateMachineAccount(bws.MachineAccount{Name: "CI"})
+	assert.NoError(t, err)
+	monitoring, err := meta.secretsManager.CreateMachineAccount(bws.MachineAccount{Name: "Monitoring"})
+	assert.NoError(t, err)
+
+	// Access granted outside of Terraform is kept.
+	_, err = meta.secretsManager.UpdateProjectAccess(project.ID, []bws.ProjectAccess{{MachineAccountID: monitoring.ID, Read: true}})
+	assert.NoError(t, err)
+
+	d := resourceSMProjectAccess().TestResourceData()
+	d.Set(attributeSMProjectID, project.ID)
+	d.Set(attributeSMMachineAccountID, ci.ID)
+	d.Set(attributeSMPermission, "read")
+
+	diags := resourceSMProjectAccessCreate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, project.ID+"/"+ci.ID, d.Id())
+
+	d.Set(attributeSMPermission, "read_write")
+	diags = resourceSMProjectAccessUpdate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	access, err := meta.secretsManager.ListProjectAccess(project.ID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []bws.ProjectAccess{
+		{MachineAccountID: monitoring.ID, Read: true},
+		{MachineAccountID: ci.ID, Read: true, Write: true},
+	}, access)
+
+	diags = resourceSMProjectAccessRead(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, "read_write", d.Get(attributeSMPermission))
+
+	diags = resourceSMProjectAccessDelete(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	access, err = meta.secretsManager.ListProjectAccess(project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []bws.ProjectAccess{{MachineAccountID: monitoring.ID, Read: true}}, access)
+
+	diags = resourceSMProjectAccessRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceSMProjectLifecycle(t *testing.T) {
+	meta, server := newTestSecretsManagerMeta(t)
+	defer server.Close()
+
+	d := resourceSMProject().TestResourceData()
+	d.Set(attributeName, "Production")
+
+	diags := resourceSMProjectCreate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, "org-1", d.Get(attributeOrganizationID))
+	assert.NotEmpty(t, d.Get(attributeRevisionDate))
+
+	d.Set(attributeName, "Staging")
+	diags = resourceSMProjectUpdate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	diags = resourceSMProjectRead(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, "Staging", d.Get(attributeName))
+
+	projectID := d.Id()
+	diags = resourceSMProjectDelete(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	d.SetId(projectID)
+	diags = resourceSMProjectRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+}
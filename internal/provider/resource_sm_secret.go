@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSMSecret() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Secrets Manager secret. Requires the `access_token` attribute to be configured on the provider.",
+
+		CreateContext: resourceSMSecretCreate,
+		ReadContext:   resourceSMSecretRead,
+		UpdateContext: resourceSMSecretUpdate,
+		DeleteContext: resourceSMSecretDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: smSecretSchema(Resource),
+	}
+}
+
+func smSecretSchema(schemaType schemaTypeEnum) map[string]*schema.Schema {
+	base := map[string]*schema.Schema{
+		attributeSMSecretKey: {
+			Description: descriptionSMSecretKey,
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		attributeSMSecretValue: {
+			Description: descriptionSMSecretValue,
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+		},
+		attributeSMSecretNote: {
+			Description: descriptionSMSecretNote,
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+		},
+		attributeSMProjectID: {
+			Description: descriptionSMSecretProjectID,
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		attributeOrganizationID: {
+			Description: descriptionSMOrganizationID,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		attributeCreationDate: {
+			Description: descriptionSMCreationDate,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		attributeRevisionDate: {
+			Description: descriptionSMRevisionDate,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+
+	if schemaType == DataSource {
+		base[attributeID] = &schema.Schema{
+			Description:  descriptionSMSecretLookupID,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{attributeID, attributeSMSecretKey},
+		}
+		base[attributeSMSecretKey] = &schema.Schema{
+			Description:  descriptionSMSecretLookupKey,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{attributeID, attributeSMSecretKey},
+		}
+		base[attributeSMProjectID] = &schema.Schema{
+			Description: descriptionSMSecretFilterProjectID,
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		}
+		base[attributeSMSecretValue].Required = false
+		base[attributeSMSecretValue].Computed = true
+		base[attributeSMSecretNote].Optional = false
+		base[attributeSMSecretNote].Computed = true
+	}
+	return base
+}
+
+func resourceSMSecretCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	secret, err := client.CreateSecret(smSecretStructFromData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smSecretDataFromStruct(d, secret))
+}
+
+func resourceSMSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	secret, err := client.GetSecret(d.Id())
+	if errors.Is(err, bws.ErrNotFound) {
+		d.SetId("")
+		log.Print("[WARN] Secret not found, removing from state")
+		return diag.Diagnostics{}
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smSecretDataFromStruct(d, secret))
+}
+
+func resourceSMSecretUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	secret, err := client.UpdateSecret(smSecretStructFromData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(smSecretDataFromStruct(d, secret))
+}
+
+func resourceSMSecretDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := secretsManagerClientFromMeta(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteSecret(d.Id())
+	if errors.Is(err, bws.ErrNotFound) {
+		return diag.Diagnostics{}
+	}
+	return diag.FromErr(err)
+}
+
+func smSecretStructFromData(d *schema.ResourceData) bws.Secret {
+	return bws.Secret{
+		ID:        d.Id(),
+		Key:       d.Get(attributeSMSecretKey).(string),
+		Value:     d.Get(attributeSMSecretValue).(string),
+		Note:      d.Get(attributeSMSecretNote).(string),
+		ProjectID: d.Get(attributeSMProjectID).(string),
+	}
+}
+
+func smSecretDataFromStruct(d *schema.ResourceData, secret *bws.Secret) error {
+	d.SetId(secret.ID)
+
+	values := map[string]string{
+		attributeSMSecretKey:    secret.Key,
+		attributeSMSecretValue:  secret.Value,
+		attributeSMSecretNote:   secret.Note,
+		attributeSMProjectID:    secret.ProjectID,
+		attributeOrganizationID: secret.OrganizationID,
+		attributeCreationDate:   formatSMDate(secret.CreationDate),
+		attributeRevisionDate:   formatSMDate(secret.RevisionDate),
+	}
+	for attribute, value := range values {
+		err := d.Set(attribute, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
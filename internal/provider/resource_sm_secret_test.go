@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceSMSecretLifecycle(t *testing.T) {
+	meta, server := newTestSecretsManagerMeta(t)
+	defer server.Close()
+
+	project, err := meta.secretsManager.CreateProject(bws.Project{Name: "Production"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	d := resourceSMSecret().TestResourceData()
+	d.Set(attributeSMSecretKey, "DB_PASSWORD")
+	d.Set(attributeSMSecretValue, "s3cr3t")
+	d.Set(attributeSMProjectID, project.ID)
+
+	diags := resourceSMSecretCreate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, project.ID, d.Get(attributeSMProjectID))
+
+	// Someone rotates the secret outside of Terraform.
+	_, err = meta.secretsManager.UpdateSecret(bws.Secret{ID: d.Id(), Key: "DB_PASSWORD", Value: "rotated", ProjectID: project.ID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	diags = resourceSMSecretRead(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	assert.Equal(t, "rotated", d.Get(attributeSMSecretValue))
+
+	d.Set(attributeSMSecretNote, "Rotated monthly")
+	diags = resourceSMSecretUpdate(context.Background(), d, meta)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	secret, err := meta.secretsManager.GetSecret(d.Id())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Rotated monthly", secret.Note)
+		assert.Equal(t, "rotated", secret.Value)
+	}
+
+	diags = resourceSMSecretDelete(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+
+	_, err = meta.secretsManager.GetSecret(secret.ID)
+	assert.ErrorIs(t, err, bws.ErrNotFound)
+}
@@ -53,8 +53,8 @@ func checkItemGeneral(resourceName string) resource.TestCheckFunc {
 		resource.TestMatchResourceAttr(
 			resourceName, fmt.Sprintf("%s.#", attributeCollectionIDs), regexp.MustCompile("^1$"),
 		),
-		resource.TestMatchResourceAttr(
-			resourceName, fmt.Sprintf("%s.0", attributeCollectionIDs), regexp.MustCompile(regExpId),
+		resource.TestCheckTypeSetElemAttr(
+			resourceName, fmt.Sprintf("%s.*", attributeCollectionIDs), testCollectionID,
 		),
 		checkItemFields(resourceName),
 	)
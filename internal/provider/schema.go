@@ -2,6 +2,7 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type schemaTypeEnum int
@@ -35,9 +36,9 @@ func baseSchema(schemaType schemaTypeEnum) map[string]*schema.Schema {
 		 */
 		attributeCollectionIDs: {
 			Description: descriptionCollectionIDs,
-			Type:        schema.TypeList,
+			Type:        collectionIDsType(schemaType),
 			Elem:        &schema.Schema{Type: schema.TypeString},
-			Computed:    schemaType == DataSource,
+			Computed:    true,
 			Optional:    schemaType == Resource,
 		},
 		attributeFavorite: {
@@ -173,6 +174,15 @@ func baseSchema(schemaType schemaTypeEnum) map[string]*schema.Schema {
 			AtLeastOneOf: []string{attributeFilterSearch, attributeID},
 		}
 	}
+
+	if schemaType == Resource {
+		base[attributeOnConflict] = &schema.Schema{
+			Description:      descriptionOnConflict,
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{onConflictFail, onConflictOverwrite}, false)),
+		}
+	}
 	return base
 }
 
@@ -205,3 +215,13 @@ func attachmentSchema() map[string]*schema.Schema {
 		},
 	}
 }
+
+// collectionIDsType returns the type of the 'collection_ids' attribute. The
+// order of collections is irrelevant to resources, while data sources keep a
+// list for configurations indexing it.
+func collectionIDsType(schemaType schemaTypeEnum) schema.ValueType {
+	if schemaType == Resource {
+		return schema.TypeSet
+	}
+	return schema.TypeList
+}
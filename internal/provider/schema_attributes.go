@@ -6,6 +6,8 @@ const (
 	attributeCollectionIDs        = "collection_ids"
 	attributeCreationDate         = "creation_date"
 	attributeDeletedDate          = "deleted_date"
+	attributeExportFile           = "export_file"
+	attributeExportPassword       = "export_password"
 	attributeID                   = "id"
 	attributeFavorite             = "favorite"
 	attributeField                = "field"
@@ -37,16 +39,80 @@ const (
 	attributeName                 = "name"
 	attributeNotes                = "notes"
 	attributeObject               = "object"
+	attributeOnConflict           = "on_conflict"
 	attributeOrganizationID       = "organization_id"
 	attributeReprompt             = "reprompt"
 	attributeRevisionDate         = "revision_date"
 	attributeType                 = "type"
 
+	// Item field resource attributes
+	attributeItemFieldItemID = "item_id"
+
+	// Item collections resource attributes
+	attributeItemCollectionsItemID = "item_id"
+
+	// Items for URL datasource attributes
+	attributeItemsForURL             = "url"
+	attributeItemsForURLDefaultMatch = "default_match"
+	attributeItemsForURLItems        = "items"
+	attributeItemsForURLMatch        = "match"
+	attributeItemsForURLURI          = "uri"
+
+	// Vault report datasource attributes
+	attributeVaultReportItems              = "items"
+	attributeVaultReportPwnedPasswordsPath = "pwned_passwords_path"
+	attributeVaultReportReasons            = "reasons"
+	attributeVaultReportWeakPasswordScore  = "weak_password_score"
+
+	// Organization policy resource attributes
+	attributeOrgPolicyEnabled = "enabled"
+	attributeOrgPolicyType    = "type"
+
+	// Secrets Manager attributes
+	attributeSMAccessTokenExpirationDate = "expiration_date"
+	attributeSMAccessTokenValue          = "value"
+	attributeSMMachineAccountID          = "machine_account_id"
+	attributeSMPermission                = "permission"
+	attributeSMProjectID                 = "project_id"
+	attributeSMSecretKey                 = "key"
+	attributeSMSecretNote                = "note"
+	attributeSMSecretValue               = "value"
+
+	// Status datasource attributes
+	attributeStatusCLIVersion = "cli_version"
+	attributeStatusLastSync   = "last_sync"
+	attributeStatusServerURL  = "server_url"
+	attributeStatusStatus     = "status"
+	attributeStatusUserEmail  = "user_email"
+	attributeStatusUserID     = "user_id"
+
+	// Organization events datasource attributes
+	attributeOrgEvents                  = "events"
+	attributeOrgEventsActingUserID      = "acting_user_id"
+	attributeOrgEventsCollectionID      = "collection_id"
+	attributeOrgEventsContinuationToken = "continuation_token"
+	attributeOrgEventsDate              = "date"
+	attributeOrgEventsDevice            = "device"
+	attributeOrgEventsDeviceName        = "device_name"
+	attributeOrgEventsEnd               = "end"
+	attributeOrgEventsGroupID           = "group_id"
+	attributeOrgEventsIPAddress         = "ip_address"
+	attributeOrgEventsItemID            = "item_id"
+	attributeOrgEventsMaxPages          = "max_pages"
+	attributeOrgEventsMemberID          = "member_id"
+	attributeOrgEventsNextToken         = "next_continuation_token"
+	attributeOrgEventsPolicyID          = "policy_id"
+	attributeOrgEventsStart             = "start"
+	attributeOrgEventsType              = "type"
+	attributeOrgEventsTypeName          = "type_name"
+
 	// Datasource and Resource field descriptions
 	descriptionAttachments            = "List of item attachments."
 	descriptionCollectionIDs          = "Identifier of the collections the item belongs to."
 	descriptionCreationDate           = "Date the item was created."
 	descriptionDeletedDate            = "Date the item was deleted."
+	descriptionExportFile             = "Path to a password-protected Bitwarden JSON export."
+	descriptionExportPassword         = "Password protecting the export. Do not commit this information in Git unless you know what you're doing. Prefer using a Terraform `variable {}` in order to inject this value from the environment."
 	descriptionFavorite               = "Mark as a Favorite to have item appear at the top of your Vault in the UI."
 	descriptionField                  = "Extra fields."
 	descriptionFieldBoolean           = "Value of a boolean field."
@@ -77,10 +143,89 @@ const (
 	descriptionLoginUsername          = "Login username."
 	descriptionName                   = "Name."
 	descriptionNotes                  = "Notes."
+	descriptionOnConflict             = "What to do when the item was modified outside of Terraform since it was last read: `fail` (default) or `overwrite`, which applies the planned changes on top of the latest version of the item."
 	descriptionOrganizationID         = "Identifier of the organization."
 	descriptionReprompt               = "Require master password “re-prompt” when displaying secret in the UI."
 	descriptionRevisionDate           = "Last time the item was updated."
 
+	// Item field resource descriptions
+	descriptionItemFieldItemID = "Identifier of the item the field belongs to."
+	descriptionItemFieldName   = "Name of the field, unique within the item."
+
+	// Item collections resource descriptions
+	descriptionItemCollectionsItemID        = "Identifier of the item, which must belong to an organization."
+	descriptionItemCollectionsCollectionIDs = "Identifier of the collections of the organization the item is assigned to."
+
+	// Items for URL datasource descriptions
+	descriptionItemsForURL             = "URL to find the login items of, e.g. the URL of a page with a login form."
+	descriptionItemsForURLDefaultMatch = "URI match detection mode used for URIs with the `default` one, like the 'Default URI match detection' setting of Bitwarden clients: `base_domain` (default), `host`, `start_with`, `exact`, `regexp` or `never`."
+	descriptionItemsForURLItems        = "Login items Bitwarden clients would autofill on the URL, in the order of the Vault."
+	descriptionItemsForURLMatch        = "URI match detection mode of the matching URI."
+	descriptionItemsForURLURI          = "First URI of the item matching the URL."
+
+	// Vault report datasource descriptions
+	descriptionVaultReportItems              = "Login items with at least one issue. Secrets are never part of the report."
+	descriptionVaultReportPwnedPasswordsPath = "Path to a local copy of the Pwned Passwords of Have I Been Pwned, to report passwords exposed in data breaches: either a file of `<SHA-1>:<count>` lines, or a directory of range files named `<first 5 characters of the SHA-1>.txt` holding `<other 35 characters>:<count>` lines. Passwords are never sent anywhere. Exposed passwords aren't reported when unset."
+	descriptionVaultReportReasons            = "Issues of the item: `exposed` (password found in the Pwned Passwords), `http_uri` (URI using unencrypted HTTP), `reused` (password used by other items of the report) and `weak` (password strength score of at most `weak_password_score`)."
+	descriptionVaultReportWeakPasswordScore  = "Passwords with a zxcvbn-style strength score, from `0` (too guessable) to `4` (very unguessable), lower or equal to this one are reported as weak (default: `2`, like the weak passwords report of Bitwarden)."
+
+	// Organization events datasource descriptions
+	descriptionOrgEvents                  = "Events of the organization matching the filters."
+	descriptionOrgEventsActingUserID      = "Identifier of the user who performed the action."
+	descriptionOrgEventsCollectionID      = "Identifier of the collection the event relates to."
+	descriptionOrgEventsContinuationToken = "Continuation token returned as `next_continuation_token` by a previous read, to resume paging from there."
+	descriptionOrgEventsDate              = "Date of the event."
+	descriptionOrgEventsDevice            = "Type of device the action was performed from."
+	descriptionOrgEventsDeviceName        = "Name of the type of device the action was performed from, e.g. `LinuxCLI`."
+	descriptionOrgEventsEnd               = "End of the time range to retrieve events for, in RFC3339 format."
+	descriptionOrgEventsFilterActingUser  = "Only retrieve events performed by this user."
+	descriptionOrgEventsFilterItemID      = "Only retrieve events related to this item."
+	descriptionOrgEventsGroupID           = "Identifier of the group the event relates to."
+	descriptionOrgEventsIPAddress         = "IP address the action was performed from."
+	descriptionOrgEventsItemID            = "Identifier of the item the event relates to."
+	descriptionOrgEventsMaxPages          = "Maximum number of pages of events to retrieve, or `0` to retrieve all of them (default: `0`)."
+	descriptionOrgEventsMemberID          = "Identifier of the organization member the event relates to."
+	descriptionOrgEventsNextToken         = "Continuation token of the next page of events, if `max_pages` was reached before the last one."
+	descriptionOrgEventsPolicyID          = "Identifier of the policy the event relates to."
+	descriptionOrgEventsStart             = "Start of the time range to retrieve events for, in RFC3339 format."
+	descriptionOrgEventsType              = "Type of event."
+	descriptionOrgEventsTypeName          = "Name of the type of event, e.g. `Cipher_ClientViewed`."
+
+	// Organization policy resource descriptions
+	descriptionOrgPolicyEnabled = "Whether the policy is enforced (default: `true`)."
+	descriptionOrgPolicyType    = "Type of policy: `activate_autofill`, `disable_personal_vault_export`, `disable_send`, `master_password`, `password_generator`, `personal_ownership`, `require_sso`, `send_options`, `single_org` or `two_step_login`. Each type of policy can only be managed once per organization."
+
+	// Secrets Manager descriptions
+	descriptionSMProjectName           = "Name of the project."
+	descriptionSMOrganizationID        = "Identifier of the organization the machine account belongs to."
+	descriptionSMSecretKey             = "Key of the secret."
+	descriptionSMSecretNote            = "Note of the secret."
+	descriptionSMSecretProjectID       = "Identifier of the project the secret belongs to. Machine accounts can only create secrets in projects they have write access to."
+	descriptionSMSecretValue           = "Value of the secret."
+	descriptionSMSecretLookupID        = "Identifier of the secret. Either `id` or `key` must be set."
+	descriptionSMSecretLookupKey       = "Key of the secret. Must match exactly one secret, optionally within `project_id`."
+	descriptionSMSecretFilterProjectID = "Only look for the secret in this project."
+	descriptionSMCreationDate          = "Date the object was created."
+	descriptionSMRevisionDate          = "Last time the object was updated."
+
+	// Secrets Manager access descriptions
+	descriptionSMAccessTokenExpirationDate = "Expiration date of the access token, in RFC3339 format. The access token never expires when unset."
+	descriptionSMAccessTokenMachineAccount = "Identifier of the machine account the access token authenticates."
+	descriptionSMAccessTokenName           = "Name of the access token."
+	descriptionSMAccessTokenValue          = "Value of the access token, only known when it gets created."
+	descriptionSMMachineAccountName        = "Name of the machine account."
+	descriptionSMProjectAccessMachineID    = "Identifier of the machine account granted access to the project."
+	descriptionSMProjectAccessPermission   = "Permission of the machine account on the project: `read` or `read_write`."
+	descriptionSMProjectAccessProjectID    = "Identifier of the project."
+
+	// Status datasource descriptions
+	descriptionStatusCLIVersion = "Version of the Bitwarden CLI used by the provider."
+	descriptionStatusLastSync   = "Last time the local Vault was synchronized with the server."
+	descriptionStatusServerURL  = "URL of the server the Vault belongs to."
+	descriptionStatusStatus     = "Status of the Vault (`locked`, `unlocked` or `unauthenticated`)."
+	descriptionStatusUserEmail  = "Email of the user the Vault belongs to."
+	descriptionStatusUserID     = "Identifier of the user the Vault belongs to."
+
 	// Provider field attributes
 	attributeClientID         = "client_id"
 	attributeClientSecret     = "client_secret"
@@ -91,15 +236,79 @@ const (
 	attributeVaultPath        = "vault_path"
 	attributeExtraCACertsPath = "extra_ca_certs"
 	attributeAPIEndpoint      = "api_endpoint"
+	attributeBWExecutable     = "bw_executable"
+	attributeBWVersion        = "bw_version_constraint"
+
+	attributeVaultPathPerIdentity = "vault_path_per_identity"
+
+	attributeSessionCache           = "session_cache"
+	attributeSessionCachePassphrase = "session_cache_passphrase"
+	attributeSessionCacheTTL        = "session_cache_ttl"
+
+	attributeOrganizationAPIKey = "organization_api_key"
+
+	attributeAccessToken = "access_token"
+
+	attributeRetryMaxAttempts = "retry_max_attempts"
+	attributeRetryBaseDelay   = "retry_base_delay"
+	attributeRetryMaxDelay    = "retry_max_delay"
+
+	attributeRegion                   = "region"
+	attributeEnvironment              = "environment"
+	attributeEnvironmentWebVault      = "web_vault"
+	attributeEnvironmentAPI           = "api"
+	attributeEnvironmentIdentity      = "identity"
+	attributeEnvironmentIcons         = "icons"
+	attributeEnvironmentNotifications = "notifications"
+	attributeEnvironmentKeyConnector  = "key_connector"
+
+	attributeTwoFactorMethod     = "two_factor_method"
+	attributeTwoFactorCode       = "two_factor_code"
+	attributeTwoFactorTOTPSecret = "two_factor_totp_secret"
+
+	attributeStoreSecretsInState = "store_secrets_in_state"
 
 	// Provider field descriptions
 	descriptionClientSecret     = "Client Secret (env: `BW_CLIENTSECRET`). Do not commit this information in Git unless you know what you're doing. Prefer using a Terraform `variable {}` in order to inject this value from the environment."
 	descriptionClientID         = "Client ID (env: `BW_CLIENTID`)"
-	descriptionEmail            = "Login Email of the Vault (env: `BW_EMAIL`)."
+	descriptionEmail            = "Login Email of the Vault (env: `BW_EMAIL`). Not needed when the provider only manages Secrets Manager objects with `access_token` or organizations with `organization_api_key`."
 	descriptionMasterPassword   = "Master password of the Vault (env: `BW_PASSWORD`). Do not commit this information in Git unless you know what you're doing. Prefer using a Terraform `variable {}` in order to inject this value from the environment."
 	descriptionServer           = "Bitwarden Server URL (default: `https://vault.bitwarden.com`, env: `BW_URL`)."
 	descriptionSessionKey       = "A Bitwarden Session Key (env: `BW_SESSION`)"
 	descriptionVaultPath        = "Alternative directory for storing the Vault locally (default: `.bitwarden/`, env: `BITWARDENCLI_APPDATA_DIR`)."
 	descriptionExtraCACertsPath = "Extends the well known 'root' CAs (like VeriSign) with the extra certificates in file (env: `NODE_EXTRA_CA_CERTS`)."
 	descriptionAPIEndpoint      = "Bitwarden CLI API endpoint which has already been logged in"
+	descriptionBWExecutable     = "Name or path of the Bitwarden CLI executable (default: `bw` from the `PATH`, env: `BW_EXECUTABLE`)."
+	descriptionBWVersion        = "Version constraint the Bitwarden CLI must satisfy, e.g. `>= 2023.2.0, < 2025.0.0` (env: `BW_VERSION_CONSTRAINT`). Ignored with `api_endpoint`, as `bw serve` doesn't expose its version."
+
+	descriptionVaultPathPerIdentity = "Store the Vault of each identity (server and email) in its own subdirectory of `vault_path`, and move a Vault stored directly in `vault_path` into it. When disabled, the provider logs out and in again whenever provider configurations with different identities share the same `vault_path` (default: `true`, env: `BW_VAULT_PATH_PER_IDENTITY`)."
+
+	descriptionSessionCache           = "Store the session key of the unlocked Vault in `vault_path` and reuse it across Terraform commands, instead of unlocking the Vault every time (default: `false`, env: `BW_SESSION_CACHE`)."
+	descriptionSessionCachePassphrase = "Passphrase used to encrypt the cached session key (default: `master_password`, env: `BW_SESSION_CACHE_PASSPHRASE`)."
+	descriptionSessionCacheTTL        = "How long a cached session key can be reused, as a Go duration (default: `1h`)."
+
+	descriptionOrganizationAPIKey             = "API key of an organization, used to manage the organization through the Bitwarden Public API (members, groups, collections and policies). When neither `master_password` nor `session_key` is set, the provider doesn't log in to a Vault."
+	descriptionOrganizationAPIKeyClientID     = "Client ID of the organization API key, e.g. `organization.<organization_id>`."
+	descriptionOrganizationAPIKeyClientSecret = "Client Secret of the organization API key. Do not commit this information in Git unless you know what you're doing. Prefer using a Terraform `variable {}` in order to inject this value from the environment."
+
+	descriptionAccessToken = "Access token of a Secrets Manager machine account, used to manage projects and secrets (env: `BWS_ACCESS_TOKEN`). When neither `master_password` nor `session_key` is set, the provider doesn't log in to a Vault and only manages Secrets Manager objects. Do not commit this information in Git unless you know what you're doing. Prefer using a Terraform `variable {}` in order to inject this value from the environment."
+
+	descriptionRetryMaxAttempts = "Maximum number of attempts for operations failing with a transient error, like rate limiting, connection resets or server errors (default: `10`). Creations and shares are only retried when they were rate limited or never reached the server, so that they are never applied twice."
+	descriptionRetryBaseDelay   = "Initial delay between two attempts, as a Go duration. The delay grows exponentially, with jitter, unless the server requests a specific one (default: `1s`)."
+	descriptionRetryMaxDelay    = "Maximum delay between two attempts, as a Go duration (default: `30s`)."
+
+	descriptionRegion                   = "Region of the Bitwarden cloud server: `us` or `eu`. Shortcut for setting `server` to `https://vault.bitwarden.com` or `https://vault.bitwarden.eu` (env: `BW_REGION`)."
+	descriptionEnvironment              = "URLs of the services of a self-hosted deployment which aren't served under the `server` URL."
+	descriptionEnvironmentWebVault      = "URL of the web vault."
+	descriptionEnvironmentAPI           = "URL of the API service (default: `<server>/api`)."
+	descriptionEnvironmentIdentity      = "URL of the identity service (default: `<server>/identity`)."
+	descriptionEnvironmentIcons         = "URL of the icons service."
+	descriptionEnvironmentNotifications = "URL of the notifications service."
+	descriptionEnvironmentKeyConnector  = "URL of the Key Connector."
+
+	descriptionTwoFactorMethod     = "Two-step login method used when logging in with `email` and `master_password`: `authenticator`, `email` or `yubikey` (default: `authenticator`, env: `BW_TWO_FACTOR_METHOD`)."
+	descriptionTwoFactorCode       = "Code of the two-step login method, only valid for a single login (env: `BW_TWO_FACTOR_CODE`)."
+	descriptionTwoFactorTOTPSecret = "Secret of the authenticator app, as a base32 string or an `otpauth://` URI, from which the provider computes the current two-step login code (env: `BW_TWO_FACTOR_TOTP_SECRET`). Do not commit this information in Git unless you know what you're doing. Prefer using a Terraform `variable {}` in order to inject this value from the environment."
+
+	descriptionStoreSecretsInState = "Store the secrets of `bitwarden_item_login` resources (`password`, `totp`, `notes` and `hidden` custom fields) in the Terraform state. When disabled, only a salted hash is stored: values from the configuration are still pushed to Bitwarden, and changes made outside of Terraform are still detected by comparing hashes (default: `true`, env: `BW_STORE_SECRETS_IN_STATE`)."
 )
@@ -49,15 +49,13 @@ func loginSchema(schemaType schemaTypeEnum) map[string]*schema.Schema {
 }
 
 func uriElem() *schema.Resource {
-	validMatchStr := []string{"default", "base_domain", "host", "start_with", "exact", "regexp", "never"}
-
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			attributeLoginURIsMatch: {
 				Description:      descriptionLoginUriMatch,
 				Type:             schema.TypeString,
-				Default:          validMatchStr[0],
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validMatchStr, false)),
+				Default:          validURIMatchStr[0],
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validURIMatchStr, false)),
 				Optional:         true,
 			},
 			attributeLoginURIsValue: {
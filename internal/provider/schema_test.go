@@ -3,6 +3,7 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,3 +24,11 @@ func TestSensisitiveFieldsAreMarkedAsSensitive(t *testing.T) {
 
 	assert.ElementsMatch(t, []string{"notes", "field", "password", "username", "totp"}, sensitiveFields)
 }
+
+func TestCollectionIDsAreOnlyASetForResources(t *testing.T) {
+	assert.Equal(t, schema.TypeSet, baseSchema(Resource)[attributeCollectionIDs].Type)
+
+	// Data sources and ephemeral resources keep a list, which can be indexed.
+	assert.Equal(t, schema.TypeList, dataSourceItemLogin().Schema[attributeCollectionIDs].Type)
+	assert.Equal(t, schema.TypeList, dataSourceItemSecureNote().Schema[attributeCollectionIDs].Type)
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// secretHashPrefix identifies secrets stored as salted hashes in the state,
+// in the format '$sha256$<salt>$<digest>'.
+const secretHashPrefix = "$sha256$"
+
+// hashedSecretAttributes are the top-level attributes of login items that are
+// only stored as hashes when 'store_secrets_in_state' is disabled. Hidden
+// custom fields are hashed too.
+var hashedSecretAttributes = []string{attributeLoginPassword, attributeLoginTotp, attributeNotes}
+
+func hashSecretsInState(meta interface{}) bool {
+	clients, ok := meta.(*bitwardenClients)
+	return ok && clients.hashSecretsInState
+}
+
+func hashSecret(value string) (string, error) {
+	salt := make([]byte, 16)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+	return hashSecretWithSalt(salt, value), nil
+}
+
+func hashSecretWithSalt(salt []byte, value string) string {
+	digest := sha256.Sum256(append(append([]byte{}, salt...), value...))
+	return secretHashPrefix + base64.RawStdEncoding.EncodeToString(salt) + "$" + base64.RawStdEncoding.EncodeToString(digest[:])
+}
+
+func isSecretHash(value string) bool {
+	return strings.HasPrefix(value, secretHashPrefix)
+}
+
+// secretMatchesHash tells whether value is the secret hash was computed from.
+func secretMatchesHash(hash, value string) bool {
+	encodedSalt, _, found := strings.Cut(strings.TrimPrefix(hash, secretHashPrefix), "$")
+	if !isSecretHash(hash) || !found {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(encodedSalt)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashSecretWithSalt(salt, value)), []byte(hash)) == 1
+}
+
+// suppressHashedSecretDiff hides the difference between a secret from the
+// configuration and its hash in the state.
+func suppressHashedSecretDiff(_, old, new string, _ *schema.ResourceData) bool {
+	return isSecretHash(old) && secretMatchesHash(old, new)
+}
+
+// withHashedSecretsSchema makes the secrets of a resource schema comparable
+// with their hashes.
+func withHashedSecretsSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	for _, attribute := range hashedSecretAttributes {
+		resourceSchema[attribute].DiffSuppressFunc = suppressHashedSecretDiff
+	}
+	resourceSchema[attributeField].Elem.(*schema.Resource).Schema[attributeFieldHidden].DiffSuppressFunc = suppressHashedSecretDiff
+	return resourceSchema
+}
+
+// withHashedSecrets wraps an operation of a resource with hashed secrets:
+//   - secrets only known as hashes are replaced by their value from the
+//     configuration before the operation, so Bitwarden always receives
+//     plaintext,
+//   - secrets read from Bitwarden are replaced by their hash after the
+//     operation, when 'store_secrets_in_state' is disabled. Hashes already in
+//     the state are kept as long as they match, so that a hash only changes
+//     when its secret does.
+func withHashedSecrets(operation func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		previous := secretsFromData(d)
+
+		err := restoreSecretsFromConfig(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		diags := operation(ctx, d, meta)
+		if diags.HasError() || len(d.Id()) == 0 || !hashSecretsInState(meta) {
+			return diags
+		}
+
+		return append(diags, diag.FromErr(hashSecretsInData(d, previous))...)
+	}
+}
+
+// secretsFromData returns the secrets of a resource, keyed by attribute or
+// by '<field>.<index>' for hidden custom fields.
+func secretsFromData(d *schema.ResourceData) map[string]string {
+	secrets := map[string]string{}
+	for _, attribute := range hashedSecretAttributes {
+		if v, ok := d.Get(attribute).(string); ok {
+			secrets[attribute] = v
+		}
+	}
+
+	fields, _ := d.Get(attributeField).([]interface{})
+	for k, v := range fields {
+		if field, ok := v.(map[string]interface{}); ok {
+			if hidden, ok := field[attributeFieldHidden].(string); ok {
+				secrets[fmt.Sprintf("%s.%d", attributeField, k)] = hidden
+			}
+		}
+	}
+	return secrets
+}
+
+func restoreSecretsFromConfig(d *schema.ResourceData) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	for _, attribute := range hashedSecretAttributes {
+		if v, ok := d.Get(attribute).(string); !ok || !isSecretHash(v) {
+			continue
+		}
+		if value, ok := ctyString(config.GetAttr(attribute)); ok {
+			err := d.Set(attribute, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	fields, _ := d.Get(attributeField).([]interface{})
+	configFields := config.GetAttr(attributeField)
+	restored := false
+	for k, v := range fields {
+		field, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if hidden, ok := field[attributeFieldHidden].(string); !ok || !isSecretHash(hidden) {
+			continue
+		}
+		if configFields.IsNull() || !configFields.IsKnown() || k >= configFields.LengthInt() {
+			continue
+		}
+		if value, ok := ctyString(configFields.Index(cty.NumberIntVal(int64(k))).GetAttr(attributeFieldHidden)); ok {
+			field[attributeFieldHidden] = value
+			restored = true
+		}
+	}
+	if restored {
+		return d.Set(attributeField, fields)
+	}
+	return nil
+}
+
+func hashSecretsInData(d *schema.ResourceData, previous map[string]string) error {
+	hash := func(key, value string) (string, error) {
+		if len(value) == 0 || isSecretHash(value) {
+			return value, nil
+		}
+		if secretMatchesHash(previous[key], value) {
+			return previous[key], nil
+		}
+		return hashSecret(value)
+	}
+
+	for _, attribute := range hashedSecretAttributes {
+		value, err := hash(attribute, d.Get(attribute).(string))
+		if err != nil {
+			return err
+		}
+		err = d.Set(attribute, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	fields, _ := d.Get(attributeField).([]interface{})
+	for k, v := range fields {
+		field := v.(map[string]interface{})
+		hidden, ok := field[attributeFieldHidden].(string)
+		if !ok {
+			continue
+		}
+
+		value, err := hash(fmt.Sprintf("%s.%d", attributeField, k), hidden)
+		if err != nil {
+			return err
+		}
+		field[attributeFieldHidden] = value
+	}
+	return d.Set(attributeField, fields)
+}
+
+func ctyString(v cty.Value) (string, bool) {
+	if v.IsNull() || !v.IsKnown() || !v.Type().Equals(cty.String) {
+		return "", false
+	}
+	return v.AsString(), true
+}
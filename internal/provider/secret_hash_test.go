@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashSecret(t *testing.T) {
+	hash, err := hashSecret("s3cr3t")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, isSecretHash(hash))
+	assert.True(t, secretMatchesHash(hash, "s3cr3t"))
+	assert.False(t, secretMatchesHash(hash, "other"))
+	assert.False(t, secretMatchesHash("s3cr3t", "s3cr3t"))
+	assert.False(t, secretMatchesHash("$sha256$not-base64!$digest", "s3cr3t"))
+
+	other, err := hashSecret("s3cr3t")
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, other, "hashes must be salted")
+}
+
+func TestSuppressHashedSecretDiff(t *testing.T) {
+	hash, err := hashSecret("s3cr3t")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, suppressHashedSecretDiff(attributeLoginPassword, hash, "s3cr3t", nil))
+	assert.False(t, suppressHashedSecretDiff(attributeLoginPassword, hash, "changed", nil))
+	assert.False(t, suppressHashedSecretDiff(attributeLoginPassword, "s3cr3t", "changed", nil))
+}
+
+func TestResourceItemLoginHashedSecrets(t *testing.T) {
+	const item = `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "notes": "notes", "login": {"username": "admin", "password": "%s", "totp": "JBSWY3DPEHPK3PXP"}, "fields": [{"name": "api_key", "value": "k3y", "type": 1}, {"name": "port", "value": "5432", "type": 0}]}`
+	meta := &bitwardenClients{Client: bw.NewClient("dummy", bw.DisableRetryBackoff()), hashSecretsInState: true}
+
+	d := resourceItemLogin().TestResourceData()
+	d.SetId("item-1")
+	assert.NoError(t, d.Set(attributeObject, bw.ObjectTypeItem))
+	assert.NoError(t, d.Set(attributeType, bw.ItemTypeLogin))
+
+	readItem := func(password string) {
+		removeMocks, _ := test_command.MockCommands(t, map[string]string{
+			"get item item-1": fmt.Sprintf(item, password),
+		})
+		defer removeMocks(t)
+
+		diags := resourceItemLogin().ReadContext(context.Background(), d, meta)
+		assert.False(t, diags.HasError(), diags)
+	}
+
+	readItem("s3cr3t")
+	password := d.Get(attributeLoginPassword).(string)
+	assert.True(t, secretMatchesHash(password, "s3cr3t"))
+	assert.True(t, secretMatchesHash(d.Get(attributeLoginTotp).(string), "JBSWY3DPEHPK3PXP"))
+	assert.True(t, secretMatchesHash(d.Get(attributeNotes).(string), "notes"))
+	assert.True(t, secretMatchesHash(d.Get("field.0.hidden").(string), "k3y"))
+	assert.Equal(t, "5432", d.Get("field.1.text"))
+	assert.Equal(t, "admin", d.Get(attributeLoginUsername))
+
+	// Hashes are stable as long as secrets don't change.
+	readItem("s3cr3t")
+	assert.Equal(t, password, d.Get(attributeLoginPassword))
+
+	// Secrets changed outside of Terraform no longer match the configuration.
+	readItem("changed")
+	assert.NotEqual(t, password, d.Get(attributeLoginPassword))
+	assert.True(t, secretMatchesHash(d.Get(attributeLoginPassword).(string), "changed"))
+	assert.False(t, suppressHashedSecretDiff(attributeLoginPassword, d.Get(attributeLoginPassword).(string), "s3cr3t", nil))
+}
+
+func TestResourceItemLoginPlaintextSecrets(t *testing.T) {
+	removeMocks, _ := test_command.MockCommands(t, map[string]string{
+		"get item item-1": `{"id": "item-1", "object": "item", "type": 1, "name": "Database", "login": {"password": "s3cr3t"}}`,
+	})
+	defer removeMocks(t)
+
+	d := resourceItemLogin().TestResourceData()
+	d.SetId("item-1")
+	assert.NoError(t, d.Set(attributeObject, bw.ObjectTypeItem))
+	assert.NoError(t, d.Set(attributeType, bw.ItemTypeLogin))
+
+	diags := resourceItemLogin().ReadContext(context.Background(), d, &bitwardenClients{Client: bw.NewClient("dummy", bw.DisableRetryBackoff())})
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "s3cr3t", d.Get(attributeLoginPassword))
+}
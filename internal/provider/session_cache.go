@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto/keybuilder"
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/webapi/crypto/symmetrickey"
+)
+
+const (
+	sessionCacheFileName      = "terraform-session.json"
+	sessionCacheKdfIterations = 100000
+	sessionCacheSaltLength    = 16
+)
+
+// sessionCache persists the session key of an unlocked Vault between
+// Terraform commands, encrypted with a key derived from a passphrase.
+type sessionCache struct {
+	path       string
+	passphrase string
+	ttl        time.Duration
+	email      string
+	serverURL  string
+}
+
+type sessionCacheFile struct {
+	Email      string    `json:"email"`
+	ServerURL  string    `json:"serverURL"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Salt       []byte    `json:"salt"`
+	SessionKey string    `json:"sessionKey"`
+}
+
+func newSessionCache(vaultPath, passphrase string, ttl time.Duration, email, serverURL string) *sessionCache {
+	return &sessionCache{
+		path:       filepath.Join(vaultPath, sessionCacheFileName),
+		passphrase: passphrase,
+		ttl:        ttl,
+		email:      email,
+		serverURL:  serverURL,
+	}
+}
+
+// Load returns the cached session key, or an empty string if there is no
+// usable session in the cache.
+func (c *sessionCache) Load() string {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("[WARN] Unable to read session cache: %v\n", err)
+		}
+		return ""
+	}
+
+	var cached sessionCacheFile
+	err = json.Unmarshal(data, &cached)
+	if err != nil {
+		log.Printf("[WARN] Unable to parse session cache: %v\n", err)
+		return ""
+	}
+
+	cachedIdentity := bw.Status{ServerURL: cached.ServerURL, UserEmail: cached.Email}
+	if !cachedIdentity.VaultOfUser(c.email) || !cachedIdentity.VaultFromServer(c.serverURL) {
+		log.Print("[DEBUG] Ignoring session cache belonging to a different identity\n")
+		return ""
+	}
+
+	if time.Now().After(cached.ExpiresAt) {
+		log.Print("[DEBUG] Ignoring expired session cache\n")
+		return ""
+	}
+
+	key, err := c.key(cached.Salt)
+	if err != nil {
+		log.Printf("[WARN] Unable to derive session cache key: %v\n", err)
+		return ""
+	}
+
+	sessionKey, err := crypto.Decrypt(cached.SessionKey, *key)
+	if err != nil {
+		// The error may include key material, keep it out of the logs.
+		log.Print("[WARN] Unable to decrypt session cache, ignoring it\n")
+		return ""
+	}
+	return string(sessionKey)
+}
+
+// Save encrypts and stores the session key, readable only by the current
+// user.
+func (c *sessionCache) Save(sessionKey string) error {
+	salt := make([]byte, sessionCacheSaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return err
+	}
+
+	key, err := c.key(salt)
+	if err != nil {
+		return err
+	}
+
+	encryptedSessionKey, err := crypto.Encrypt([]byte(sessionKey), *key)
+	if err != nil {
+		return fmt.Errorf("error encrypting session key: %w", err)
+	}
+
+	data, err := json.Marshal(sessionCacheFile{
+		Email:      c.email,
+		ServerURL:  c.serverURL,
+		ExpiresAt:  time.Now().Add(c.ttl),
+		Salt:       salt,
+		SessionKey: encryptedSessionKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(c.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that concurrent readers never
+	// see a partially written cache.
+	tmpPath := fmt.Sprintf("%s.%d", c.path, os.Getpid())
+	err = os.WriteFile(tmpPath, data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, c.path)
+}
+
+func (c *sessionCache) key(salt []byte) (*symmetrickey.Key, error) {
+	// The key builder takes a string salt, used for emails by Bitwarden.
+	key, err := keybuilder.BuildPreloginKey(c.passphrase, hex.EncodeToString(salt), sessionCacheKdfIterations)
+	if err != nil {
+		return nil, err
+	}
+	return key.StretchKey()
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	test_command "github.com/geNAZt/terraform-provider-bitwarden/internal/command/test"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionCacheRoundTrip(t *testing.T) {
+	vaultPath := t.TempDir()
+
+	cache := newSessionCache(vaultPath, "passphrase", time.Hour, "test@laverse.net", "http://127.0.0.1/")
+	assert.Empty(t, cache.Load())
+	assert.NoError(t, cache.Save("session-key1234"))
+	assert.Equal(t, "session-key1234", cache.Load())
+
+	fi, err := os.Stat(filepath.Join(vaultPath, sessionCacheFileName))
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	}
+
+	otherPassphrase := newSessionCache(vaultPath, "other-passphrase", time.Hour, "test@laverse.net", "http://127.0.0.1/")
+	assert.Empty(t, otherPassphrase.Load())
+
+	otherUser := newSessionCache(vaultPath, "passphrase", time.Hour, "other@laverse.net", "http://127.0.0.1/")
+	assert.Empty(t, otherUser.Load())
+
+	otherServer := newSessionCache(vaultPath, "passphrase", time.Hour, "test@laverse.net", "http://127.0.0.2/")
+	assert.Empty(t, otherServer.Load())
+}
+
+func TestSessionCacheDecryptionErrorsAreNotLogged(t *testing.T) {
+	vaultPath := t.TempDir()
+	assert.NoError(t, newSessionCache(vaultPath, "passphrase", time.Hour, "test@laverse.net", "http://127.0.0.1/").Save("session-key1234"))
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	assert.Empty(t, newSessionCache(vaultPath, "other-passphrase", time.Hour, "test@laverse.net", "http://127.0.0.1/").Load())
+	assert.Contains(t, logs.String(), "Unable to decrypt session cache, ignoring it")
+	assert.NotContains(t, strings.ToLower(logs.String()), "mac")
+}
+
+func TestSessionCacheExpires(t *testing.T) {
+	cache := newSessionCache(t.TempDir(), "passphrase", -time.Minute, "test@laverse.net", "http://127.0.0.1/")
+	assert.NoError(t, cache.Save("session-key1234"))
+	assert.Empty(t, cache.Load())
+}
+
+func TestProviderReusesCachedSession(t *testing.T) {
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{
+		"status": `{"serverURL": "http://127.0.0.1/", "userEmail": "test@laverse.net", "status": "unlocked"}`,
+	})
+	defer removeMocks(t)
+
+	vaultPath := t.TempDir()
+	cache := newSessionCache(identityVaultPath(vaultPath, "http://127.0.0.1/", "test@laverse.net"), "master-password-9", time.Hour, "test@laverse.net", "http://127.0.0.1/")
+	assert.NoError(t, cache.Save("session-key1234"))
+
+	providerConfiguration := map[string]interface{}{
+		"server":          "http://127.0.0.1/",
+		"email":           "test@laverse.net",
+		"master_password": "master-password-9",
+		"vault_path":      vaultPath,
+		"session_cache":   true,
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(providerConfiguration))
+
+	if !assert.False(t, diag.HasError()) {
+		t.Fatalf("unexpected error: %v", diag[0])
+	}
+
+	assert.Equal(t, []string{
+		"status",
+	}, commandsExecuted())
+}
+
+func TestProviderCachesSessionAfterUnlock(t *testing.T) {
+	removeMocks, commandsExecuted := test_command.MockCommands(t, map[string]string{
+		"status":                                 `{"serverURL": "http://127.0.0.1/", "userEmail": "test@laverse.net", "status": "locked"}`,
+		"unlock --raw --passwordenv BW_PASSWORD": `session-key5678`,
+	})
+	defer removeMocks(t)
+
+	vaultPath := t.TempDir()
+	providerConfiguration := map[string]interface{}{
+		"server":                   "http://127.0.0.1/",
+		"email":                    "test@laverse.net",
+		"master_password":          "master-password-9",
+		"vault_path":               vaultPath,
+		"session_cache":            true,
+		"session_cache_passphrase": "passphrase",
+	}
+
+	diag := New(versionDev)().Configure(context.Background(), terraform.NewResourceConfigRaw(providerConfiguration))
+
+	if !assert.False(t, diag.HasError()) {
+		t.Fatalf("unexpected error: %v", diag[0])
+	}
+
+	assert.Equal(t, []string{
+		"status",
+		"unlock --raw --passwordenv BW_PASSWORD",
+	}, commandsExecuted())
+
+	cache := newSessionCache(identityVaultPath(vaultPath, "http://127.0.0.1/", "test@laverse.net"), "passphrase", time.Hour, "test@laverse.net", "http://127.0.0.1/")
+	assert.Equal(t, "session-key5678", cache.Load())
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+)
+
+// errPasswordManagerNotConfigured is returned when managing Password Manager
+// objects with a provider only configured for Secrets Manager or the Public
+// API.
+var errPasswordManagerNotConfigured = fmt.Errorf("the '%s' attribute and either '%s' or '%s' must be configured on the provider to manage Password Manager objects", attributeEmail, attributeMasterPassword, attributeSessionKey)
+
+// unconfiguredClient stands in for the Vault client of providers configured
+// with a machine account access token or an organization API key only, which
+// never log in to a Vault.
+type unconfiguredClient struct{}
+
+func (unconfiguredClient) CreateAttachment(string, string) (*bw.Object, error) {
+	return nil, errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) CreateObject(bw.Object) (*bw.Object, error) {
+	return nil, errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) EditItemCollections(string, []string) (*bw.Object, error) {
+	return nil, errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) EditObject(bw.Object) (*bw.Object, error) {
+	return nil, errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) GetAttachment(string, string) ([]byte, error) {
+	return nil, errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) GetObject(bw.Object) (*bw.Object, error) {
+	return nil, errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) GetSessionKey() string {
+	return ""
+}
+
+func (unconfiguredClient) ListObjects(string, ...bw.ListObjectsOption) ([]bw.Object, error) {
+	return nil, errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) LoginWithAPIKey(string, string, string) error {
+	return errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) LoginWithPassword(string, string, ...bw.LoginOption) error {
+	return errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) Logout() error {
+	return errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) DeleteAttachment(string, string) error {
+	return errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) DeleteObject(bw.Object) error {
+	return errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) SetServer(bw.Environment) error {
+	return errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) SetSessionKey(string) {}
+
+func (unconfiguredClient) ShareObject(string, string, []string) (*bw.Object, error) {
+	return nil, errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) Status() (*bw.Status, error) {
+	return nil, errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) Sync() error {
+	return errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) Unlock(string) error {
+	return errPasswordManagerNotConfigured
+}
+
+func (unconfiguredClient) Version() (string, error) {
+	return "", errPasswordManagerNotConfigured
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	vaultDataFileName      = "data.json"
+	identityVaultDirPrefix = "vault-"
+)
+
+// vaultPathFromData returns the directory in which the Bitwarden CLI stores
+// the Vault. Unless disabled, every identity (server and email) gets its own
+// subdirectory of 'vault_path', so that provider aliases sharing the same
+// 'vault_path' don't log each other out.
+func vaultPathFromData(d *schema.ResourceData) (string, error) {
+	vaultPath, err := filepath.Abs(d.Get(attributeVaultPath).(string))
+	if err != nil {
+		return "", err
+	}
+
+	if !d.Get(attributeVaultPathPerIdentity).(bool) {
+		return vaultPath, nil
+	}
+
+	env, err := environmentFromData(d)
+	if err != nil {
+		return "", err
+	}
+	return identityVaultPath(vaultPath, env.Server, d.Get(attributeEmail).(string)), nil
+}
+
+// identityVaultPath returns the subdirectory of vaultPath dedicated to an
+// identity. Identities are hashed to keep the directory name short and free
+// of special characters.
+func identityVaultPath(vaultPath, serverURL, email string) string {
+	if len(serverURL) == 0 {
+		serverURL = bw.DefaultBitwardenServerURL
+	}
+	identity := fmt.Sprintf("%s\n%s", strings.TrimSuffix(serverURL, "/"), strings.ToLower(email))
+
+	sum := sha256.Sum256([]byte(identity))
+	return filepath.Join(vaultPath, identityVaultDirPrefix+hex.EncodeToString(sum[:8]))
+}
+
+// migrateSharedVault moves a Vault stored directly in vaultPath, as done by
+// previous versions of the provider, into the subdirectory of the identity it
+// belongs to. vaultStatus returns the status of the Vault stored in a given
+// directory.
+//
+// Nothing is done once the Vault of the identity being configured, stored in
+// currentIdentityPath, exists: a shared Vault left behind then belongs to
+// someone else, and reading its status on every run would be wasted.
+func migrateSharedVault(vaultPath, currentIdentityPath string, vaultStatus func(dir string) (*bw.Status, error)) error {
+	if _, err := os.Stat(filepath.Join(currentIdentityPath, vaultDataFileName)); err == nil {
+		return nil
+	}
+
+	sharedDataFile := filepath.Join(vaultPath, vaultDataFileName)
+	if _, err := os.Stat(sharedDataFile); errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	status, err := vaultStatus(vaultPath)
+	if err != nil {
+		return fmt.Errorf("unable to read status of the Vault in '%s': %w", vaultPath, err)
+	}
+
+	// There is nothing worth keeping in a Vault nobody is logged in.
+	if status.Status == bw.StatusUnauthenticated || len(status.UserEmail) == 0 {
+		return nil
+	}
+
+	identityPath := identityVaultPath(vaultPath, status.ServerURL, status.UserEmail)
+	identityDataFile := filepath.Join(identityPath, vaultDataFileName)
+	if _, err := os.Stat(identityDataFile); err == nil {
+		log.Printf("[INFO] Not migrating Vault of '%s' from '%s', as '%s' already exists\n", status.UserEmail, vaultPath, identityPath)
+		return nil
+	}
+
+	err = os.MkdirAll(identityPath, 0700)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Migrating Vault of '%s' from '%s' to '%s'\n", status.UserEmail, vaultPath, identityPath)
+	err = os.Rename(sharedDataFile, identityDataFile)
+	if errors.Is(err, os.ErrNotExist) {
+		// Another provider instance sharing the same 'vault_path' was faster.
+		return nil
+	}
+	return err
+}
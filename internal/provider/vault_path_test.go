@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/bitwarden/bw"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityVaultPath(t *testing.T) {
+	path := identityVaultPath("/vault", "https://vault.bitwarden.com/", "Test@laverse.net")
+
+	assert.Equal(t, "/vault", filepath.Dir(path))
+	assert.Equal(t, path, identityVaultPath("/vault", "", "test@laverse.net"))
+	assert.NotEqual(t, path, identityVaultPath("/vault", "https://vault.bitwarden.eu", "test@laverse.net"))
+	assert.NotEqual(t, path, identityVaultPath("/vault", "https://vault.bitwarden.com", "other@laverse.net"))
+}
+
+func TestMigrateSharedVault(t *testing.T) {
+	vaultPath := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, vaultDataFileName), []byte(`{}`), 0600))
+
+	err := migrateSharedVault(vaultPath, identityVaultPath(vaultPath, "http://127.0.0.1/", "test@laverse.net"), func(dir string) (*bw.Status, error) {
+		assert.Equal(t, vaultPath, dir)
+		return &bw.Status{ServerURL: "http://127.0.0.1/", UserEmail: "test@laverse.net", Status: bw.StatusLocked}, nil
+	})
+	assert.NoError(t, err)
+
+	assert.NoFileExists(t, filepath.Join(vaultPath, vaultDataFileName))
+	assert.FileExists(t, filepath.Join(identityVaultPath(vaultPath, "http://127.0.0.1/", "test@laverse.net"), vaultDataFileName))
+}
+
+func TestMigrateSharedVaultKeepsExistingIdentityVault(t *testing.T) {
+	vaultPath := t.TempDir()
+	identityPath := identityVaultPath(vaultPath, "http://127.0.0.1/", "test@laverse.net")
+	assert.NoError(t, os.MkdirAll(identityPath, 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(identityPath, vaultDataFileName), []byte(`{"identity": true}`), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, vaultDataFileName), []byte(`{}`), 0600))
+
+	err := migrateSharedVault(vaultPath, identityVaultPath(vaultPath, "http://127.0.0.1/", "other@laverse.net"), func(dir string) (*bw.Status, error) {
+		return &bw.Status{ServerURL: "http://127.0.0.1/", UserEmail: "test@laverse.net", Status: bw.StatusLocked}, nil
+	})
+	assert.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(vaultPath, vaultDataFileName))
+	data, err := os.ReadFile(filepath.Join(identityPath, vaultDataFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"identity": true}`, string(data))
+}
+
+func TestMigrateSharedVaultWithoutVault(t *testing.T) {
+	vaultPath := t.TempDir()
+	err := migrateSharedVault(vaultPath, identityVaultPath(vaultPath, "http://127.0.0.1/", "test@laverse.net"), func(dir string) (*bw.Status, error) {
+		t.Fatal("status shouldn't be read when there is no Vault")
+		return nil, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestMigrateSharedVaultSkippedOnceIdentityVaultExists(t *testing.T) {
+	vaultPath := t.TempDir()
+	identityPath := identityVaultPath(vaultPath, "http://127.0.0.1/", "test@laverse.net")
+	assert.NoError(t, os.MkdirAll(identityPath, 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(identityPath, vaultDataFileName), []byte(`{}`), 0600))
+
+	// A shared Vault of another identity left behind.
+	assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, vaultDataFileName), []byte(`{}`), 0600))
+
+	err := migrateSharedVault(vaultPath, identityPath, func(dir string) (*bw.Status, error) {
+		t.Fatal("status shouldn't be read once the Vault of the identity exists")
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(vaultPath, vaultDataFileName))
+}
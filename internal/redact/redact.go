@@ -0,0 +1,149 @@
+// Package redact masks secrets before they reach the provider's logs.
+package redact
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+const Mask = "<redacted>"
+
+var (
+	// SensitiveEnvVars are environment variables holding secrets passed to
+	// the Bitwarden CLI.
+	SensitiveEnvVars = []string{
+		"BW_CLIENTSECRET",
+		"BW_PASSWORD",
+		"BW_SESSION",
+	}
+
+	// sensitiveFlags are flags of the Bitwarden CLI whose values are secrets.
+	sensitiveFlags = map[string]bool{
+		"--code": true,
+	}
+
+	// sensitiveKeys are JSON keys whose values are secrets in Bitwarden
+	// objects.
+	sensitiveKeys = map[string]bool{
+		"code":           true,
+		"licenseNumber":  true,
+		"notes":          true,
+		"number":         true,
+		"passportNumber": true,
+		"password":       true,
+		"ssn":            true,
+		"totp":           true,
+	}
+)
+
+// fieldTypeHidden is the type of custom fields holding secrets, see
+// bw.FieldTypeHidden.
+const fieldTypeHidden = 1
+
+// Args masks the arguments of a command which are base64-encoded JSON
+// payloads, as generated by 'bw encode', or values of sensitive flags.
+func Args(args []string) []string {
+	redacted := make([]string, len(args))
+	for k, arg := range args {
+		if isEncodedPayload(arg) || k > 0 && sensitiveFlags[args[k-1]] {
+			redacted[k] = Mask
+		} else {
+			redacted[k] = arg
+		}
+	}
+	return redacted
+}
+
+// EnvSecrets returns the values of sensitive environment variables found in
+// the given list of KEY=VALUE entries.
+func EnvSecrets(env []string) []string {
+	secrets := []string{}
+	for _, entry := range env {
+		key, value, found := strings.Cut(entry, "=")
+		if !found || len(value) == 0 {
+			continue
+		}
+		for _, sensitive := range SensitiveEnvVars {
+			if key == sensitive {
+				secrets = append(secrets, value)
+			}
+		}
+	}
+	return secrets
+}
+
+// String masks every occurrence of the given secrets.
+func String(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if len(secret) > 0 {
+			s = strings.ReplaceAll(s, secret, Mask)
+		}
+	}
+	return s
+}
+
+// JSON masks the values of sensitive keys and hidden fields in a JSON
+// document. Documents which aren't valid JSON are returned as-is, unless they
+// are base64-encoded JSON payloads.
+func JSON(data []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		if isEncodedPayload(strings.TrimSpace(string(data))) {
+			return Mask
+		}
+		return string(data)
+	}
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redactValue(v)); err != nil {
+		return Mask
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if sensitiveKeys[key] && value != nil {
+				t[key] = Mask
+				continue
+			}
+			t[key] = redactValue(value)
+		}
+
+		// Values of hidden custom fields.
+		if fieldType, ok := t["type"].(float64); ok && int(fieldType) == fieldTypeHidden {
+			if _, isField := t["name"]; isField {
+				if value, hasValue := t["value"]; hasValue && value != nil {
+					t["value"] = Mask
+				}
+			}
+		}
+		return t
+	case []interface{}:
+		for k, value := range t {
+			t[k] = redactValue(value)
+		}
+		return t
+	}
+	return v
+}
+
+func isEncodedPayload(s string) bool {
+	if len(s) < 4 {
+		return false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return false
+	}
+
+	decoded = []byte(strings.TrimSpace(string(decoded)))
+	return len(decoded) > 0 && (decoded[0] == '{' || decoded[0] == '[') && json.Valid(decoded)
+}
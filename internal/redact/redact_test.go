@@ -0,0 +1,63 @@
+package redact
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgs(t *testing.T) {
+	payload := base64.StdEncoding.EncodeToString([]byte(`{"login":{"password":"s3cr3t"}}`))
+
+	assert.Equal(t, []string{"edit", "item", "0f4b6c2e-8d1a-4c3b-9e5f-7a6d5c4b3a21", Mask}, Args([]string{"edit", "item", "0f4b6c2e-8d1a-4c3b-9e5f-7a6d5c4b3a21", payload}))
+	assert.Equal(t, []string{"list", "items", "--search", "test"}, Args([]string{"list", "items", "--search", "test"}))
+	assert.Equal(t, []string{"login", "me@example.com", "--method", "0", "--code", Mask}, Args([]string{"login", "me@example.com", "--method", "0", "--code", "123456"}))
+}
+
+func TestEnvSecrets(t *testing.T) {
+	secrets := EnvSecrets([]string{"BW_PASSWORD=s3cr3t", "BW_SESSION=session-key", "BITWARDENCLI_APPDATA_DIR=/tmp", "BW_CLIENTSECRET="})
+
+	assert.Equal(t, []string{"s3cr3t", "session-key"}, secrets)
+	assert.Equal(t, "unlocked with <redacted> (<redacted>)", String("unlocked with s3cr3t (session-key)", secrets...))
+}
+
+func TestJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "item",
+			input:    `{"name":"login","notes":"my notes","login":{"username":"me","password":"s3cr3t","totp":"otpauth://totp"}}`,
+			expected: `{"login":{"password":"<redacted>","totp":"<redacted>","username":"me"},"name":"login","notes":"<redacted>"}`,
+		},
+		{
+			name:     "fields",
+			input:    `{"data":{"fields":[{"name":"visible","value":"text","type":0},{"name":"secret","value":"hidden","type":1}]}}`,
+			expected: `{"data":{"fields":[{"name":"visible","type":0,"value":"text"},{"name":"secret","type":1,"value":"<redacted>"}]}}`,
+		},
+		{
+			name:     "null values",
+			input:    `{"notes":null}`,
+			expected: `{"notes":null}`,
+		},
+		{
+			name:     "not json",
+			input:    "Not found.",
+			expected: "Not found.",
+		},
+		{
+			name:     "encoded payload",
+			input:    base64.StdEncoding.EncodeToString([]byte(`{"notes":"my notes"}`)) + "\n",
+			expected: Mask,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, JSON([]byte(test.input)))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// transientErrorMessages are fragments of error messages reported by the
+// Bitwarden CLI (and Node.js underneath it) for errors worth retrying.
+var transientErrorMessages = []string{
+	"Rate limit exceeded.",
+	"ECONNRESET",
+	"ETIMEDOUT",
+	"EPIPE",
+	"socket hang up",
+	"connection reset by peer",
+	"TLS handshake timeout",
+	"Too Many Requests",
+	"Bad Gateway",
+	"Service Unavailable",
+	"Gateway Timeout",
+}
+
+// unsentErrorMessages are fragments of error messages reported by the
+// Bitwarden CLI for errors raised before a request reached the server, or
+// for requests the server refused to process.
+var unsentErrorMessages = []string{
+	"Rate limit exceeded.",
+	"ECONNREFUSED",
+	"ENOTFOUND",
+	"EAI_AGAIN",
+	"Too Many Requests",
+}
+
+// HTTPError is returned for HTTP responses which indicate a server-side
+// or throttling issue.
+type HTTPError struct {
+	StatusCode int
+	RetryAfter time.Duration
+
+	// Body is left out of the error message, as it may echo the request
+	// and end up in diagnostics.
+	Body string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("bad status code: %d (%s)", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// IsTransient returns true if the error is likely to disappear by retrying
+// the operation.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode != http.StatusNotImplemented
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ETIMEDOUT) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	msg := err.Error()
+	for _, fragment := range transientErrorMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUnsent returns true if the error ensures the operation wasn't applied,
+// i.e. if the request was throttled or couldn't be sent. Only these errors
+// can be retried for operations which aren't idempotent, like creations, as
+// the server may have processed requests whose response got lost.
+func IsUnsent(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	msg := err.Error()
+	for _, fragment := range unsentErrorMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryAfter returns the delay requested by the server, if any.
+func RetryAfter(err error) time.Duration {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.RetryAfter
+	}
+	return 0
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// CheckResponse returns an *HTTPError if the response indicates a throttling
+// or server-side issue, in which case the response body is consumed and closed.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		Body:       string(body),
+	}
+}
@@ -0,0 +1,99 @@
+package retry
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// sleep is only meant to be changed during tests.
+var sleep = time.Sleep
+
+// Policy describes how many times, and how long to wait before, a failed
+// operation is retried. Delays grow exponentially from BaseDelay up to
+// MaxDelay, with full jitter.
+type Policy struct {
+	MaxAttempts    int
+	BaseDelay      time.Duration
+	MaxDelay       time.Duration
+	DisableBackoff bool
+
+	// NonIdempotent restricts retries to errors ensuring the operation
+	// wasn't applied, see IsUnsent.
+	NonIdempotent bool
+}
+
+// DefaultPolicy returns the policy used when none is configured.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: 10,
+		BaseDelay:   1 * time.Second,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// ForNonIdempotent returns a copy of the policy for operations which can't
+// safely run twice, like creations.
+func (p Policy) ForNonIdempotent() Policy {
+	p.NonIdempotent = true
+	return p
+}
+
+// IsRetryable returns true if the error is transient and the operation
+// hasn't been attempted too many times yet. Errors ensuring the request
+// wasn't sent can be retried by any operation.
+func (p Policy) IsRetryable(err error, attempt int) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if p.NonIdempotent {
+		return IsUnsent(err)
+	}
+	return IsTransient(err) || IsUnsent(err)
+}
+
+// Backoff waits before the next attempt and returns the time waited.
+func (p Policy) Backoff(err error, attempt int) time.Duration {
+	delay := p.Delay(err, attempt)
+	sleep(delay)
+	return delay
+}
+
+// Delay returns how long to wait before the next attempt. A delay requested
+// by the server (e.g. with a 'Retry-After' header) takes precedence, as long
+// as it doesn't exceed MaxDelay.
+func (p Policy) Delay(err error, attempt int) time.Duration {
+	if p.DisableBackoff {
+		return 0
+	}
+
+	if retryAfter := RetryAfter(err); retryAfter > 0 {
+		return min(retryAfter, p.MaxDelay)
+	}
+
+	ceiling := time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt-1)))
+	if ceiling <= 0 || ceiling > p.MaxDelay {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// Do runs fn until it succeeds, fails with an error that can't be retried,
+// or the policy's maximum number of attempts is reached.
+func Do[T any](p Policy, fn func() (T, error)) (T, error) {
+	attempts := 0
+	for {
+		attempts = attempts + 1
+		out, err := fn()
+		if err == nil || !p.IsRetryable(err, attempts) {
+			return out, err
+		}
+
+		p.Backoff(err, attempts)
+		log.Printf("[ERROR] Retrying after error: %v\n", err)
+	}
+}
@@ -0,0 +1,187 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransient(t *testing.T) {
+	testCases := []struct {
+		err       error
+		transient bool
+	}{
+		{err: errors.New("Rate limit exceeded. Try again later."), transient: true},
+		{err: errors.New("request to https://vault.bitwarden.com/api/sync failed, reason: read ECONNRESET"), transient: true},
+		{err: errors.New("request to https://vault.bitwarden.com/api/sync failed, reason: socket hang up"), transient: true},
+		{err: errors.New("net/http: TLS handshake timeout"), transient: true},
+		{err: fmt.Errorf("wrapped: %w", syscall.ECONNRESET), transient: true},
+		{err: &HTTPError{StatusCode: 503}, transient: true},
+		{err: &HTTPError{StatusCode: 501}, transient: false},
+		{err: errors.New("Not found."), transient: false},
+		{err: errors.New("Invalid master password."), transient: false},
+		{err: nil, transient: false},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.transient, IsTransient(test.err), "%v", test.err)
+	}
+}
+
+func TestIsUnsent(t *testing.T) {
+	testCases := []struct {
+		err    error
+		unsent bool
+	}{
+		{err: errors.New("Rate limit exceeded. Try again later."), unsent: true},
+		{err: errors.New("request to https://vault.bitwarden.com/api/ciphers failed, reason: connect ECONNREFUSED 127.0.0.1:443"), unsent: true},
+		{err: errors.New("request to https://vault.bitwarden.com/api/ciphers failed, reason: getaddrinfo ENOTFOUND vault.bitwarden.com"), unsent: true},
+		{err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, unsent: true},
+		{err: &net.DNSError{Err: "no such host", Name: "vault.bitwarden.com"}, unsent: true},
+		{err: &HTTPError{StatusCode: 429}, unsent: true},
+		{err: &HTTPError{StatusCode: 503}, unsent: false},
+		{err: errors.New("request to https://vault.bitwarden.com/api/ciphers failed, reason: read ECONNRESET"), unsent: false},
+		{err: errors.New("request to https://vault.bitwarden.com/api/ciphers failed, reason: socket hang up"), unsent: false},
+		{err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, unsent: false},
+		{err: nil, unsent: false},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.unsent, IsUnsent(test.err), "%v", test.err)
+	}
+}
+
+func TestHTTPErrorHidesBody(t *testing.T) {
+	err := &HTTPError{StatusCode: 502, Body: `{"password": "secret"}`}
+	assert.EqualError(t, err, "bad status code: 502 (Bad Gateway)")
+}
+
+func TestCheckResponse(t *testing.T) {
+	testCases := []struct {
+		statusCode int
+		retryAfter string
+		expected   error
+	}{
+		{statusCode: 200},
+		{statusCode: 400},
+		{statusCode: 404},
+		{statusCode: 429, retryAfter: "7", expected: &HTTPError{StatusCode: 429, RetryAfter: 7 * time.Second, Body: "body"}},
+		{statusCode: 429, retryAfter: "garbage", expected: &HTTPError{StatusCode: 429, Body: "body"}},
+		{statusCode: 502, expected: &HTTPError{StatusCode: 502, Body: "body"}},
+	}
+
+	for _, test := range testCases {
+		rec := httptest.NewRecorder()
+		if len(test.retryAfter) > 0 {
+			rec.Header().Set("Retry-After", test.retryAfter)
+		}
+		rec.WriteHeader(test.statusCode)
+		rec.WriteString("body")
+
+		err := CheckResponse(rec.Result())
+		if test.expected == nil {
+			assert.NoError(t, err)
+		} else {
+			assert.Equal(t, test.expected, err)
+		}
+	}
+}
+
+func TestPolicyDelay(t *testing.T) {
+	policy := Policy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	for attempt := 1; attempt < 10; attempt++ {
+		delay := policy.Delay(errors.New("ECONNRESET"), attempt)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, min(time.Duration(1<<(attempt-1))*time.Second, policy.MaxDelay))
+	}
+
+	assert.Equal(t, 4*time.Second, policy.Delay(&HTTPError{StatusCode: 429, RetryAfter: 4 * time.Second}, 1))
+	assert.Equal(t, policy.MaxDelay, policy.Delay(&HTTPError{StatusCode: 429, RetryAfter: time.Hour}, 1))
+
+	policy.DisableBackoff = true
+	assert.Equal(t, time.Duration(0), policy.Delay(&HTTPError{StatusCode: 429, RetryAfter: 4 * time.Second}, 1))
+}
+
+func TestPolicyIsRetryable(t *testing.T) {
+	policy := Policy{MaxAttempts: 3}
+
+	testCases := []struct {
+		err                    error
+		retryable              bool
+		retryableNonIdempotent bool
+	}{
+		{err: errors.New("request to https://vault.bitwarden.com/api/sync failed, reason: connect ECONNREFUSED 127.0.0.1:443"), retryable: true, retryableNonIdempotent: true},
+		{err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, retryable: true, retryableNonIdempotent: true},
+		{err: &net.DNSError{Err: "no such host", Name: "vault.bitwarden.com"}, retryable: true, retryableNonIdempotent: true},
+		{err: errors.New("socket hang up"), retryable: true, retryableNonIdempotent: false},
+		{err: &HTTPError{StatusCode: 503}, retryable: true, retryableNonIdempotent: false},
+		{err: errors.New("Not found."), retryable: false, retryableNonIdempotent: false},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.retryable, policy.IsRetryable(test.err, 1), "%v", test.err)
+		assert.Equal(t, test.retryableNonIdempotent, policy.ForNonIdempotent().IsRetryable(test.err, 1), "%v", test.err)
+		assert.False(t, policy.IsRetryable(test.err, policy.MaxAttempts), "%v", test.err)
+	}
+}
+
+func TestDo(t *testing.T) {
+	var slept []time.Duration
+	sleep = func(d time.Duration) { slept = append(slept, d) }
+	defer func() { sleep = time.Sleep }()
+
+	policy := Policy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	calls := 0
+	_, err := Do(policy, func() (any, error) {
+		calls = calls + 1
+		return nil, &HTTPError{StatusCode: http.StatusServiceUnavailable, RetryAfter: 2 * time.Second}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, slept)
+
+	calls = 0
+	_, err = Do(policy, func() (any, error) {
+		calls = calls + 1
+		return nil, errors.New("Not found.")
+	})
+	assert.EqualError(t, err, "Not found.")
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	out, err := Do(policy, func() (string, error) {
+		calls = calls + 1
+		if calls < 2 {
+			return "", errors.New("socket hang up")
+		}
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", out)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	_, err = Do(policy.ForNonIdempotent(), func() (any, error) {
+		calls = calls + 1
+		return nil, errors.New("socket hang up")
+	})
+	assert.EqualError(t, err, "socket hang up")
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	_, err = Do(policy.ForNonIdempotent(), func() (any, error) {
+		calls = calls + 1
+		return nil, &HTTPError{StatusCode: http.StatusTooManyRequests}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+}
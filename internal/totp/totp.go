@@ -0,0 +1,154 @@
+// Package totp generates time-based one-time passwords (RFC 6238) from the
+// kind of secrets Bitwarden stores in the 'totp' attribute of login items.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const steamAlphabet = "23456789BCDFGHJKMNPQRTVWXY"
+
+// Key holds the parameters needed to generate codes.
+type Key struct {
+	Secret    []byte
+	Algorithm string
+	Digits    int
+	Period    time.Duration
+	Steam     bool
+}
+
+// ParseKey accepts a base32 secret, an 'otpauth://totp/...' URI or a
+// 'steam://<secret>' URI.
+func ParseKey(value string) (*Key, error) {
+	value = strings.TrimSpace(value)
+	key := &Key{
+		Algorithm: "SHA1",
+		Digits:    6,
+		Period:    30 * time.Second,
+	}
+
+	secret := value
+	switch {
+	case strings.HasPrefix(strings.ToLower(value), "steam://"):
+		secret = value[len("steam://"):]
+		key.Steam = true
+		key.Digits = 5
+
+	case strings.HasPrefix(strings.ToLower(value), "otpauth://"):
+		u, err := url.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid otpauth URI: %w", err)
+		}
+		if u.Host != "totp" {
+			return nil, fmt.Errorf("unsupported otpauth URI type '%s', only 'totp' is supported", u.Host)
+		}
+
+		q := u.Query()
+		secret = q.Get("secret")
+		if algorithm := q.Get("algorithm"); len(algorithm) > 0 {
+			key.Algorithm = strings.ToUpper(algorithm)
+		}
+		if digits := q.Get("digits"); len(digits) > 0 {
+			key.Digits, err = strconv.Atoi(digits)
+			if err != nil || key.Digits < 1 || key.Digits > 10 {
+				return nil, fmt.Errorf("invalid number of digits: '%s'", digits)
+			}
+		}
+		if period := q.Get("period"); len(period) > 0 {
+			seconds, err := strconv.Atoi(period)
+			if err != nil || seconds < 1 {
+				return nil, fmt.Errorf("invalid period: '%s'", period)
+			}
+			key.Period = time.Duration(seconds) * time.Second
+		}
+	}
+
+	decoded, err := decodeSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	key.Secret = decoded
+
+	if _, err := key.hash(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Code returns the code valid at the given time.
+func (k *Key) Code(t time.Time) string {
+	newHash, _ := k.hash()
+	counter := uint64(t.Unix() / int64(k.Period/time.Second))
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(newHash, k.Secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	if k.Steam {
+		code := make([]byte, k.Digits)
+		for i := range code {
+			code[i] = steamAlphabet[value%uint32(len(steamAlphabet))]
+			value = value / uint32(len(steamAlphabet))
+		}
+		return string(code)
+	}
+
+	mod := uint64(1)
+	for i := 0; i < k.Digits; i++ {
+		mod = mod * 10
+	}
+	return fmt.Sprintf("%0*d", k.Digits, uint64(value)%mod)
+}
+
+// GenerateCode returns the code valid at the given time for a secret in any
+// of the formats accepted by ParseKey.
+func GenerateCode(value string, t time.Time) (string, error) {
+	key, err := ParseKey(value)
+	if err != nil {
+		return "", err
+	}
+	return key.Code(t), nil
+}
+
+func (k *Key) hash() (func() hash.Hash, error) {
+	switch k.Algorithm {
+	case "SHA1":
+		return sha1.New, nil
+	case "SHA256":
+		return sha256.New, nil
+	case "SHA512":
+		return sha512.New, nil
+	}
+	return nil, fmt.Errorf("unsupported algorithm '%s'", k.Algorithm)
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(secret))
+	secret = strings.TrimRight(secret, "=")
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("empty TOTP secret")
+	}
+
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOTP secret, expected base32: %w", err)
+	}
+	return decoded, nil
+}
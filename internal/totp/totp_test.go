@@ -0,0 +1,74 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test vectors from RFC 6238, Appendix B.
+func TestGenerateCodeRFC6238(t *testing.T) {
+	secrets := map[string]string{
+		"SHA1":   "12345678901234567890",
+		"SHA256": "12345678901234567890123456789012",
+		"SHA512": "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+
+	testCases := []struct {
+		unix      int64
+		algorithm string
+		expected  string
+	}{
+		{unix: 59, algorithm: "SHA1", expected: "94287082"},
+		{unix: 59, algorithm: "SHA256", expected: "46119246"},
+		{unix: 59, algorithm: "SHA512", expected: "90693936"},
+		{unix: 1111111109, algorithm: "SHA1", expected: "07081804"},
+		{unix: 1234567890, algorithm: "SHA256", expected: "91819424"},
+		{unix: 20000000000, algorithm: "SHA512", expected: "47863826"},
+	}
+
+	for _, test := range testCases {
+		secret := base32.StdEncoding.EncodeToString([]byte(secrets[test.algorithm]))
+		uri := "otpauth://totp/Example:alice@example.com?secret=" + secret + "&algorithm=" + test.algorithm + "&digits=8"
+
+		code, err := GenerateCode(uri, time.Unix(test.unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, code, "%s at %d", test.algorithm, test.unix)
+	}
+}
+
+func TestGenerateCodeFromPlainSecret(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	code, err := GenerateCode(secret, time.Unix(59, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	// Secrets are often displayed in lower case and grouped by four.
+	code, err = GenerateCode("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", code)
+}
+
+func TestGenerateCodeSteam(t *testing.T) {
+	code, err := GenerateCode("steam://GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", time.Unix(59, 0))
+
+	assert.NoError(t, err)
+	assert.Len(t, code, 5)
+	for _, c := range code {
+		assert.Contains(t, steamAlphabet, string(c))
+	}
+}
+
+func TestParseKeyErrors(t *testing.T) {
+	_, err := ParseKey("otpauth://hotp/Example?secret=GEZDGNBV&counter=1")
+	assert.EqualError(t, err, "unsupported otpauth URI type 'hotp', only 'totp' is supported")
+
+	_, err = ParseKey("otpauth://totp/Example?secret=GEZDGNBV&algorithm=MD5")
+	assert.EqualError(t, err, "unsupported algorithm 'MD5'")
+
+	_, err = ParseKey("not base32!")
+	assert.ErrorContains(t, err, "invalid TOTP secret")
+}
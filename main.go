@@ -4,9 +4,11 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
+	"github.com/geNAZt/terraform-provider-bitwarden/internal/generator"
 	"github.com/geNAZt/terraform-provider-bitwarden/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 //go:generate terraform fmt -recursive ./examples/
@@ -24,20 +26,31 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == generator.CommandName {
+		err := generator.Run(context.Background(), os.Args[2:], os.Stdout)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debugMode bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{ProviderFunc: provider.New(version)}
+	muxServer, err := provider.NewMuxServer(context.Background(), version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 
+	var serveOpts []tf5server.ServeOpt
 	if debugMode {
-		err := plugin.Debug(context.Background(), providerAddr, opts)
-		if err != nil {
-			log.Fatal(err.Error())
-		}
-		return
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	err = tf5server.Serve(providerAddr, muxServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }